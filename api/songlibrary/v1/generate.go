@@ -0,0 +1,5 @@
+// Package songlibraryv1 is the generated code of the SongLibrary gRPC service.
+// Regenerate it with protoc, protoc-gen-go and protoc-gen-go-grpc after changing songlibrary.proto.
+package songlibraryv1
+
+//go:generate protoc -I ../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative songlibrary/v1/songlibrary.proto
@@ -0,0 +1,377 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: songlibrary/v1/songlibrary.proto
+
+package songlibraryv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SongLibrary_Save_FullMethodName       = "/songlibrary.v1.SongLibrary/Save"
+	SongLibrary_Info_FullMethodName       = "/songlibrary.v1.SongLibrary/Info"
+	SongLibrary_Update_FullMethodName     = "/songlibrary.v1.SongLibrary/Update"
+	SongLibrary_Delete_FullMethodName     = "/songlibrary.v1.SongLibrary/Delete"
+	SongLibrary_List_FullMethodName       = "/songlibrary.v1.SongLibrary/List"
+	SongLibrary_StreamList_FullMethodName = "/songlibrary.v1.SongLibrary/StreamList"
+	SongLibrary_Text_FullMethodName       = "/songlibrary.v1.SongLibrary/Text"
+)
+
+// SongLibraryClient is the client API for SongLibrary service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SongLibrary is the gRPC API of the library, it mirrors the REST API.
+// Credentials are sent in the x-api-key or authorization ("Bearer <JWT>") metadata.
+// Errors are returned with the codes InvalidArgument, NotFound, AlreadyExists,
+// Unauthenticated, PermissionDenied and Internal.
+type SongLibraryClient interface {
+	// Save adds a new song, like POST /songs. Requires the editor role.
+	Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*SaveResponse, error)
+	// Info returns the details of a song, like GET /info.
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	// Update replaces the details of a song, like PUT /songs. Requires the editor role.
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	// Delete deletes a song, like DELETE /songs. Requires the admin role.
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	// List returns a page of the songs matching the filter, like GET /songs.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// StreamList streams all songs matching the filter, they are read from the database in pages.
+	StreamList(ctx context.Context, in *StreamListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SongWithDetail], error)
+	// Text returns a page of the song text, like GET /songs/text.
+	Text(ctx context.Context, in *TextRequest, opts ...grpc.CallOption) (*TextResponse, error)
+}
+
+type songLibraryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSongLibraryClient(cc grpc.ClientConnInterface) SongLibraryClient {
+	return &songLibraryClient{cc}
+}
+
+func (c *songLibraryClient) Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*SaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_Save_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_Info_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songLibraryClient) StreamList(ctx context.Context, in *StreamListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SongWithDetail], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SongLibrary_ServiceDesc.Streams[0], SongLibrary_StreamList_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamListRequest, SongWithDetail]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SongLibrary_StreamListClient = grpc.ServerStreamingClient[SongWithDetail]
+
+func (c *songLibraryClient) Text(ctx context.Context, in *TextRequest, opts ...grpc.CallOption) (*TextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TextResponse)
+	err := c.cc.Invoke(ctx, SongLibrary_Text_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SongLibraryServer is the server API for SongLibrary service.
+// All implementations must embed UnimplementedSongLibraryServer
+// for forward compatibility.
+//
+// SongLibrary is the gRPC API of the library, it mirrors the REST API.
+// Credentials are sent in the x-api-key or authorization ("Bearer <JWT>") metadata.
+// Errors are returned with the codes InvalidArgument, NotFound, AlreadyExists,
+// Unauthenticated, PermissionDenied and Internal.
+type SongLibraryServer interface {
+	// Save adds a new song, like POST /songs. Requires the editor role.
+	Save(context.Context, *SaveRequest) (*SaveResponse, error)
+	// Info returns the details of a song, like GET /info.
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	// Update replaces the details of a song, like PUT /songs. Requires the editor role.
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	// Delete deletes a song, like DELETE /songs. Requires the admin role.
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	// List returns a page of the songs matching the filter, like GET /songs.
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// StreamList streams all songs matching the filter, they are read from the database in pages.
+	StreamList(*StreamListRequest, grpc.ServerStreamingServer[SongWithDetail]) error
+	// Text returns a page of the song text, like GET /songs/text.
+	Text(context.Context, *TextRequest) (*TextResponse, error)
+	mustEmbedUnimplementedSongLibraryServer()
+}
+
+// UnimplementedSongLibraryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSongLibraryServer struct{}
+
+func (UnimplementedSongLibraryServer) Save(context.Context, *SaveRequest) (*SaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Save not implemented")
+}
+func (UnimplementedSongLibraryServer) Info(context.Context, *InfoRequest) (*InfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedSongLibraryServer) Update(context.Context, *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedSongLibraryServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedSongLibraryServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedSongLibraryServer) StreamList(*StreamListRequest, grpc.ServerStreamingServer[SongWithDetail]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamList not implemented")
+}
+func (UnimplementedSongLibraryServer) Text(context.Context, *TextRequest) (*TextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Text not implemented")
+}
+func (UnimplementedSongLibraryServer) mustEmbedUnimplementedSongLibraryServer() {}
+func (UnimplementedSongLibraryServer) testEmbeddedByValue()                     {}
+
+// UnsafeSongLibraryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SongLibraryServer will
+// result in compilation errors.
+type UnsafeSongLibraryServer interface {
+	mustEmbedUnimplementedSongLibraryServer()
+}
+
+func RegisterSongLibraryServer(s grpc.ServiceRegistrar, srv SongLibraryServer) {
+	// If the following call pancis, it indicates UnimplementedSongLibraryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SongLibrary_ServiceDesc, srv)
+}
+
+func _SongLibrary_Save_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).Save(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_Save_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).Save(ctx, req.(*SaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_Info_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongLibrary_StreamList_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SongLibraryServer).StreamList(m, &grpc.GenericServerStream[StreamListRequest, SongWithDetail]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SongLibrary_StreamListServer = grpc.ServerStreamingServer[SongWithDetail]
+
+func _SongLibrary_Text_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongLibraryServer).Text(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongLibrary_Text_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongLibraryServer).Text(ctx, req.(*TextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SongLibrary_ServiceDesc is the grpc.ServiceDesc for SongLibrary service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SongLibrary_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "songlibrary.v1.SongLibrary",
+	HandlerType: (*SongLibraryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Save",
+			Handler:    _SongLibrary_Save_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _SongLibrary_Info_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _SongLibrary_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _SongLibrary_Delete_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _SongLibrary_List_Handler,
+		},
+		{
+			MethodName: "Text",
+			Handler:    _SongLibrary_Text_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamList",
+			Handler:       _SongLibrary_StreamList_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "songlibrary/v1/songlibrary.proto",
+}
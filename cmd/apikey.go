@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"song-library/internal/apikey"
+	"song-library/internal/config"
+	"song-library/internal/storage"
+	"song-library/internal/storage/postgres"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const apiKeyUsage = `Usage:
+  song-library apikey create <name>   Create a new API key, the key is printed once
+  song-library apikey revoke <id>     Revoke the API key
+  song-library apikey list            List API keys`
+
+// runAPIKey manages API keys of the mutating endpoints.
+// It returns the exit code of the command.
+func runAPIKey(cfg *config.Config, log *slog.Logger, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, apiKeyUsage)
+		return 2
+	}
+
+	pgStorage, err := postgres.New(cfg, log)
+	if err != nil {
+		log.Error("Error opening storage", slog.Any("error", err))
+		return 1
+	}
+	defer pgStorage.Close(log)
+
+	switch {
+	case args[0] == "create" && len(args) == 2:
+		return apiKeyCreate(pgStorage, args[1])
+	case args[0] == "revoke" && len(args) == 2:
+		return apiKeyRevoke(pgStorage, args[1])
+	case args[0] == "list" && len(args) == 1:
+		return apiKeyList(pgStorage)
+	default:
+		fmt.Fprintln(os.Stderr, apiKeyUsage)
+		return 2
+	}
+}
+
+func apiKeyCreate(pgStorage *postgres.Storage, name string) int {
+	key, hash, err := apikey.Generate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to generate API key:", err)
+		return 1
+	}
+
+	keyID, err := pgStorage.APIKeyCreate(context.Background(), name, hash)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to save API key:", err)
+		return 1
+	}
+
+	fmt.Printf("API key %d created: %s\n", keyID, key)
+	fmt.Println("Save the key now, it cannot be shown again.")
+
+	return 0
+}
+
+func apiKeyRevoke(pgStorage *postgres.Storage, id string) int {
+	keyID, err := strconv.Atoi(id)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Incorrect API key id:", id)
+		return 2
+	}
+
+	err = pgStorage.APIKeyRevoke(context.Background(), keyID)
+	if err != nil {
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			fmt.Fprintf(os.Stderr, "API key %d not found or already revoked\n", keyID)
+			return 1
+		}
+
+		fmt.Fprintln(os.Stderr, "Unable to revoke API key:", err)
+		return 1
+	}
+
+	fmt.Printf("API key %d revoked\n", keyID)
+
+	return 0
+}
+
+func apiKeyList(pgStorage *postgres.Storage) int {
+	apiKeys, err := pgStorage.APIKeys(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to list API keys:", err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tCREATED\tREVOKED")
+
+	for _, apiKey := range apiKeys {
+		revoked := "-"
+		if apiKey.RevokedAt != nil {
+			revoked = apiKey.RevokedAt.Format(time.DateTime)
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n",
+			apiKey.ID, apiKey.Name, apiKey.CreatedAt.Format(time.DateTime), revoked)
+	}
+
+	_ = tw.Flush()
+
+	return 0
+}
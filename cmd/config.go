@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"song-library/internal/config"
+)
+
+const configUsage = `Usage:
+  song-library config print   Print the effective config as YAML, secrets are redacted`
+
+// runConfig inspects the config loaded from defaults, the config file, environment and flags.
+// It returns the exit code of the command.
+func runConfig(cfg *config.Config, args []string) int {
+	if len(args) != 1 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, configUsage)
+		return 2
+	}
+
+	if err := cfg.Print(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to print config:", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/tls"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+	"log/slog"
+	"net"
+	"os"
+	songlibraryv1 "song-library/api/songlibrary/v1"
+	"song-library/internal/config"
+	"song-library/internal/grpc-server/interceptors"
+	"song-library/internal/grpc-server/songlibrary"
+	"song-library/internal/http-server/mwauth"
+	"song-library/internal/http-server/mwratelimit"
+	"time"
+)
+
+// rateLimitStores are the token buckets shared by the REST and gRPC APIs, so a client has one limit on both.
+type rateLimitStores struct {
+	ip    mwratelimit.Store
+	read  mwratelimit.Store
+	write mwratelimit.Store
+}
+
+// newGRPCServer returns the gRPC server of the SongLibrary service with the same authentication, roles,
+// rate limits, metrics, logging and tracing as the REST API. It serves TLS if tlsConfig is not nil.
+func newGRPCServer(cfg *config.Config, log *slog.Logger, service *songlibrary.Server, apiKeyFinder mwauth.APIKeyFinder,
+	tokenVerifier mwauth.TokenVerifier, stores rateLimitStores, registerer prometheus.Registerer,
+	tlsConfig *tls.Config) *grpc.Server {
+	writes := songlibrary.Writes()
+
+	tracingUnary, tracingStream := interceptors.Tracing(otel.GetTracerProvider(), otel.GetTextMapPropagator())
+	loggerUnary, loggerStream := interceptors.Logger(log)
+	metricsUnary, metricsStream := interceptors.Metrics(registerer)
+	recoveryUnary, recoveryStream := interceptors.Recovery(log)
+	// Limited before authentication, so calls with invalid credentials are limited too
+	ipLimitUnary, ipLimitStream := interceptors.RateLimit(log, stores.ip,
+		mwratelimit.Limit{Rate: cfg.RateLimitIPRPS, Burst: cfg.RateLimitIPBurst}, interceptors.ByPeerIP, nil)
+	authInterceptor := interceptors.NewAuth(log, apiKeyFinder, tokenVerifier, songlibrary.Roles(cfg.AuthPublicRead))
+	readLimitUnary, readLimitStream := interceptors.RateLimit(log, stores.read,
+		mwratelimit.Limit{Rate: cfg.RateLimitReadRPS, Burst: cfg.RateLimitReadBurst}, interceptors.ByClient,
+		func(method string) bool { return !writes[method] })
+	writeLimitUnary, writeLimitStream := interceptors.RateLimit(log, stores.write,
+		mwratelimit.Limit{Rate: cfg.RateLimitWriteRPS, Burst: cfg.RateLimitWriteBurst}, interceptors.ByClient,
+		func(method string) bool { return writes[method] })
+
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(tracingUnary, loggerUnary, metricsUnary, recoveryUnary, ipLimitUnary,
+			authInterceptor.Unary(), readLimitUnary, writeLimitUnary),
+		grpc.ChainStreamInterceptor(tracingStream, loggerStream, metricsStream, recoveryStream, ipLimitStream,
+			authInterceptor.Stream(), readLimitStream, writeLimitStream),
+	}
+
+	if tlsConfig != nil {
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := grpc.NewServer(options...)
+
+	songlibraryv1.RegisterSongLibraryServer(server, service)
+	// Lets grpcurl and other clients discover the service without the proto file
+	reflection.Register(server)
+
+	return server
+}
+
+// startGRPCServer starts serving on the gRPC address. If the server stops serving, a signal is sent to stop.
+func startGRPCServer(cfg *config.Config, log *slog.Logger, server *grpc.Server, stop chan<- os.Signal) error {
+	listener, err := net.Listen("tcp", cfg.GrpcAddress)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Start gRPC server", slog.String("Address", cfg.GrpcAddress))
+
+	go serve(log, stop, func() error {
+		return server.Serve(listener)
+	})
+
+	return nil
+}
+
+// stopGRPCServer waits for in-flight calls up to the timeout and then cancels the rest,
+// as streams of large listings may take longer.
+func stopGRPCServer(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		server.Stop()
+	}
+}
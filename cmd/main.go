@@ -2,33 +2,76 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
 	"log/slog"
-	"net/http"
 	"os"
 	"os/signal"
+	"song-library/internal/auth"
 	"song-library/internal/config"
+	"song-library/internal/events"
+	"song-library/internal/grpc-server/songlibrary"
+	eventsget "song-library/internal/http-server/handlers/events/get"
+	graphqlhandler "song-library/internal/http-server/handlers/graphql"
+	"song-library/internal/http-server/handlers/health"
 	songinfo "song-library/internal/http-server/handlers/info/get"
+	jobget "song-library/internal/http-server/handlers/jobs/get"
 	songdelete "song-library/internal/http-server/handlers/songs/delete"
 	songsget "song-library/internal/http-server/handlers/songs/get"
 	songsave "song-library/internal/http-server/handlers/songs/save"
 	songtext "song-library/internal/http-server/handlers/songs/text"
 	songupdate "song-library/internal/http-server/handlers/songs/update"
+	translationsget "song-library/internal/http-server/handlers/translations/get"
+	translationsave "song-library/internal/http-server/handlers/translations/save"
+	translationupdate "song-library/internal/http-server/handlers/translations/update"
+	webhookdelete "song-library/internal/http-server/handlers/webhooks/delete"
+	webhookdeliveries "song-library/internal/http-server/handlers/webhooks/deliveries"
+	webhooksget "song-library/internal/http-server/handlers/webhooks/get"
+	webhooksave "song-library/internal/http-server/handlers/webhooks/save"
+	"song-library/internal/http-server/httpcache"
+	"song-library/internal/http-server/mwauth"
+	"song-library/internal/http-server/mwcompress"
 	"song-library/internal/http-server/mwlogger"
+	"song-library/internal/http-server/mwmetrics"
+	"song-library/internal/http-server/mwratelimit"
+	"song-library/internal/http-server/mwtracing"
+	"song-library/internal/jobs"
+	"song-library/internal/linkcheck"
 	"song-library/internal/logger/slogger"
+	"song-library/internal/metrics"
+	"song-library/internal/musicinfo"
+	"song-library/internal/outbox"
 	"song-library/internal/storage/postgres"
+	"song-library/internal/tracing"
+	"song-library/internal/webhooks"
 	"syscall"
+	"time"
 )
 
 func main() {
 	// Config
-	cfg := config.MustLoad()
+	cfg, args := config.MustLoad(os.Args[1:])
 
 	// Logger
 	log := slogger.SetupLogger(cfg.Environment)
 
-	log.Info("Starting songs library REST API server", slog.String("Environment", cfg.Environment))
+	// Commands
+	if len(args) > 0 {
+		os.Exit(runCommand(cfg, log, args))
+	}
+
+	log.Info("Starting songs library REST and gRPC API server", slog.String("Environment", cfg.Environment))
+
+	// Tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg, log)
+	if err != nil {
+		log.Error("Error setting up tracing", slog.Any("error", err))
+		os.Exit(1)
+	}
 
 	// Storage
 	log.Debug("Start connect to storage")
@@ -43,56 +86,286 @@ func main() {
 
 	_ = storage
 
+	// Health
+	schemaVersion, err := postgres.LatestMigrationVersion()
+	if err != nil {
+		log.Error("Error reading migrations", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	healthState := &health.State{}
+
+	// Authentication
+	jwtVerifier, err := auth.NewJWTVerifier(cfg)
+	if err != nil {
+		log.Error("Error loading JWT keys", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if !jwtVerifier.Enabled() {
+		log.Info("JWT authentication disabled, only API keys are accepted")
+	}
+
+	// Metrics
+	registry := metrics.NewRegistry()
+	registry.MustRegister(
+		metrics.NewDBStatsCollector(storage),
+		metrics.NewLibraryCollector(storage),
+	)
+
+	// Background jobs
+	jobPool := jobs.New(jobs.Options{
+		Workers:         cfg.JobWorkers,
+		PollInterval:    cfg.JobPollInterval,
+		Timeout:         cfg.JobTimeout,
+		MaxAttempts:     cfg.JobMaxAttempts,
+		RetryBackoff:    cfg.JobRetryBackoff,
+		RetryMaxBackoff: cfg.JobRetryMaxBackoff,
+	}, storage, log)
+
+	// Enrichment of new songs by background jobs
+	var songJobs jobs.Enqueuer
+	if cfg.MusicInfoURL != "" {
+		musicInfoClient, err := musicinfo.New(cfg, log)
+		if err != nil {
+			log.Error("Error creating music info client", slog.Any("error", err))
+			os.Exit(1)
+		}
+
+		songEnricher := musicinfo.NewEnricher(musicInfoClient, storage, log)
+		jobPool.Register(musicinfo.JobKindEnrich, songEnricher.HandleJob)
+
+		songJobs = jobPool
+	}
+
+	// Webhooks on song lifecycle events, delivered by background jobs
+	webhookDispatcher := webhooks.New(storage, jobPool, cfg.WebhookTimeout, log)
+	jobPool.Register(webhooks.JobKindDeliver, webhookDispatcher.HandleJob)
+
+	// Change feed of song events broadcast by all server replicas
+	eventBroker := events.New(storage, cfg.EventsRetention, log)
+
+	// Relay of the song events added to the outbox in the transactions of the changes,
+	// it drives the webhooks and publishes the events to the sink set by OUTBOX_SINK
+	outboxSinks := outbox.Sinks{outbox.SinkFunc(webhookDispatcher.SongEvent)}
+
+	outboxSink, err := outbox.NewSink(cfg)
+	if err != nil {
+		log.Error("Error creating outbox sink", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	if outboxSink != nil {
+		outboxSinks = append(outboxSinks, outboxSink)
+	}
+
+	outboxRelay := outbox.New(outbox.Options{
+		PollInterval:    cfg.OutboxPollInterval,
+		BatchSize:       cfg.OutboxBatchSize,
+		Timeout:         cfg.OutboxTimeout,
+		RetryBackoff:    cfg.OutboxRetryBackoff,
+		RetryMaxBackoff: cfg.OutboxRetryMaxBackoff,
+		Retention:       cfg.OutboxRetention,
+	}, storage, outboxSinks, log)
+
+	// Dead link checker
+	linkChecker := linkcheck.New(linkcheck.Options{
+		Interval:    cfg.LinkCheckInterval,
+		MaxAge:      cfg.LinkCheckMaxAge,
+		Concurrency: cfg.LinkCheckConcurrency,
+		Timeout:     cfg.LinkCheckTimeout,
+		BatchSize:   cfg.LinkCheckBatchSize,
+	}, storage, log)
+
 	// Router
 	router := chi.NewRouter()
 
 	// middleware
 	router.Use(middleware.RequestID)
 	router.Use(middleware.RealIP)
+	router.Use(mwtracing.New(otel.GetTracerProvider(), otel.GetTextMapPropagator()))
 	router.Use(mwlogger.New(log))
+	router.Use(mwmetrics.New(registry))
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
+	router.Use(mwcompress.New(cfg.CompressMinSize))
+	// Buckets of the clients are shared with the gRPC server
+	limitStores := rateLimitStores{
+		ip:    mwratelimit.NewMemoryStore(),
+		read:  mwratelimit.NewMemoryStore(),
+		write: mwratelimit.NewMemoryStore(),
+	}
+
+	// Limited before authentication, so requests with invalid credentials are limited too
+	router.Use(mwratelimit.New(log, limitStores.ip,
+		mwratelimit.Limit{Rate: cfg.RateLimitIPRPS, Burst: cfg.RateLimitIPBurst}, mwratelimit.ByIP))
+	router.Use(mwauth.New(log, storage, jwtVerifier))
 
-	// Paths
-	router.Get("/info", songinfo.New(log, storage))
-	router.Get("/songs", songsget.New(log, storage))
-	router.Post("/songs", songsave.New(log, storage, cfg.Address))
-	router.Put("/songs", songupdate.New(log, storage))
-	router.Delete("/songs", songdelete.New(log, storage))
-	router.Get("/songs/text", songtext.New(log, cfg.Address))
+	// Rate limiters of the clients, writes of editors and admins share the same limit.
+	// They are used before mwauth.Require, so the requests without the role are limited too.
+	readLimiter := mwratelimit.New(log, limitStores.read,
+		mwratelimit.Limit{Rate: cfg.RateLimitReadRPS, Burst: cfg.RateLimitReadBurst}, mwratelimit.ByClient)
+	writeLimiter := mwratelimit.New(log, limitStores.write,
+		mwratelimit.Limit{Rate: cfg.RateLimitWriteRPS, Burst: cfg.RateLimitWriteBurst}, mwratelimit.ByClient)
+
+	// Probes and Prometheus metrics
+	router.Get("/healthz", health.Live())
+	router.Get("/readyz", health.Ready(log, storage, healthState, schemaVersion))
+	router.Handle("/metrics", metrics.Handler(registry, log))
+
+	// Paths for readers, public unless AUTH_PUBLIC_READ=false
+	router.Group(func(r chi.Router) {
+		r.Use(readLimiter)
+		if !cfg.AuthPublicRead {
+			r.Use(mwauth.Require(log, auth.RoleReader))
+		}
+		r.Use(httpcache.CacheControl(cfg.CacheMaxAge, cfg.AuthPublicRead))
+
+		r.Get("/info", songinfo.New(log, storage, storage))
+		r.Get("/songs", songsget.New(log, storage))
+		r.Get("/songs/text", songtext.New(log, storage, storage))
+		r.Get("/songs/translations", translationsget.New(log, storage))
+		r.Get("/events", eventsget.New(log, storage, eventBroker, cfg.EventsHeartbeat))
+	})
+
+	// GraphQL reads are public unless AUTH_PUBLIC_READ=false, mutations check the roles
+	// of the REST routes and take the write tokens
+	router.Group(func(r chi.Router) {
+		r.Use(graphqlhandler.Limit(readLimiter, writeLimiter))
+		if !cfg.AuthPublicRead {
+			r.Use(mwauth.Require(log, auth.RoleReader))
+		}
+
+		r.Post("/graphql", graphqlhandler.New(log, storage, songJobs))
+	})
+
+	// Paths for editors
+	router.Group(func(r chi.Router) {
+		r.Use(writeLimiter)
+		r.Use(mwauth.Require(log, auth.RoleEditor))
+
+		r.Post("/songs", songsave.New(log, storage, songJobs))
+		r.Put("/songs", songupdate.New(log, storage))
+		r.Post("/songs/translations", translationsave.New(log, storage))
+		r.Put("/songs/translations", translationupdate.New(log, storage))
+		r.Get("/jobs/{id}", jobget.New(log, storage))
+	})
+
+	// Paths for admins
+	router.Group(func(r chi.Router) {
+		r.Use(writeLimiter)
+		r.Use(mwauth.Require(log, auth.RoleAdmin))
+
+		r.Delete("/songs", songdelete.New(log, storage))
+
+		r.Post("/webhooks", webhooksave.New(log, storage))
+		r.Get("/webhooks", webhooksget.New(log, storage))
+		r.Delete("/webhooks/{id}", webhookdelete.New(log, storage))
+		r.Get("/webhooks/{id}/deliveries", webhookdeliveries.New(log, storage))
+	})
 
 	// Channel to graceful shutdown
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
 	// Server
-	log.Info("Start server", slog.String("Address", cfg.Address))
+	server, err := newServer(cfg, log, router)
+	if err != nil {
+		log.Error("Error configuring server", slog.Any("error", err))
+		os.Exit(1)
+	}
 
-	server := &http.Server{
-		Addr:         cfg.Address,
-		Handler:      router,
-		ReadTimeout:  cfg.Timeout,
-		WriteTimeout: cfg.Timeout,
-		IdleTimeout:  cfg.IdleTimeout,
+	if err = startServer(cfg, log, server, stop); err != nil {
+		log.Error("Error starting server", slog.Any("error", err))
+		os.Exit(1)
 	}
 
-	go func() {
-		if err := server.ListenAndServe(); err != nil {
-			log.Info("Server is not running", slog.Any("reason", err))
-			stop <- syscall.SIGINT
+	// gRPC server on its own port, over the same storage as the REST API
+	var grpcServer *grpc.Server
+	if cfg.GrpcAddress != "" {
+		var tlsConfig *tls.Config
+		if server.TLSConfig != nil {
+			tlsConfig = server.TLSConfig.Clone()
 		}
-	}()
+
+		songLibrary := songlibrary.New(log, storage, songJobs)
+		grpcServer = newGRPCServer(cfg, log, songLibrary, storage, jwtVerifier, limitStores, registry, tlsConfig)
+
+		if err = startGRPCServer(cfg, log, grpcServer, stop); err != nil {
+			log.Error("Error starting gRPC server", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+
+	jobPool.Start()
+	linkChecker.Start()
+	eventBroker.Start()
+	outboxRelay.Start()
 
 	// Graceful shutdown
 	sign := <-stop
 
 	log.Info("Stopping server", slog.String("signal", sign.String()))
 
+	// Fail the readiness probe and wait for the orchestrator
+	// to stop sending traffic before closing the listener
+	healthState.SetShuttingDown()
+
+	log.Info("Draining traffic", slog.String("delay", cfg.ShutdownDrainDelay.String()))
+
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	// Event streams never end by themselves and would block the shutdown
+	eventBroker.Stop()
+
 	if err := server.Shutdown(context.Background()); err != nil {
 		log.Error("Failed to stop server", slog.Any("error", err))
 	}
 
+	if grpcServer != nil {
+		stopGRPCServer(grpcServer, cfg.Timeout)
+	}
+
+	linkChecker.Stop()
+	outboxRelay.Stop()
+
+	// Jobs not finished in time are cancelled and retried after restart
+	jobsCtx, cancelJobs := context.WithTimeout(context.Background(), cfg.JobShutdownTimeout)
+	if err := jobPool.Stop(jobsCtx); err != nil {
+		log.Error("Failed to finish running jobs", slog.Any("error", err))
+	}
+	cancelJobs()
+
 	storage.Close(log)
 
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Error("Failed to stop tracing", slog.Any("error", err))
+	}
+
 	log.Info("Server stopped")
 }
+
+const usage = `Usage:
+  song-library [flags]                   Start REST API server
+  song-library [flags] apikey <command>  Manage API keys
+  song-library [flags] migrate <command> Manage database schema migrations
+  song-library [flags] config print      Print the effective config, secrets are redacted
+
+Every config variable is also set by a flag, e.g. PG_HOST by -pg-host,
+-config sets the YAML config file. Run 'song-library -h' for the list of flags.`
+
+// runCommand runs the command given in the command line arguments
+// and returns the exit code.
+func runCommand(cfg *config.Config, log *slog.Logger, args []string) int {
+	switch args[0] {
+	case "apikey":
+		return runAPIKey(cfg, log, args[1:])
+	case "migrate":
+		return runMigrate(cfg, log, args[1:])
+	case "config":
+		return runConfig(cfg, args[1:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+}
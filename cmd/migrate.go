@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"song-library/internal/config"
+	"song-library/internal/storage/postgres"
+	"strconv"
+)
+
+const migrateUsage = `Usage:
+  song-library migrate up [N]      Apply all pending migrations or N migrations
+  song-library migrate down [N]    Roll back the last migration or N migrations
+  song-library migrate goto <V>    Migrate up or down to version V
+  song-library migrate version     Print the current schema version
+  song-library migrate force <V>   Set version V and clear the dirty flag without running migrations,
+                                   -1 resets the schema to no applied migrations`
+
+// runMigrate manages the database schema with the migrations embedded into the binary.
+// It returns the exit code of the command.
+func runMigrate(cfg *config.Config, log *slog.Logger, args []string) int {
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	var arg *int
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		// Only force accepts -1, the version before the first migration
+		if err != nil || n < 0 && !(args[0] == "force" && n == -1) {
+			fmt.Fprintln(os.Stderr, "Incorrect number:", args[1])
+			return 2
+		}
+		arg = &n
+	}
+
+	// The command controls migrations itself, they must not be applied on connect
+	migrateCfg := *cfg
+	migrateCfg.AutoMigrate = false
+
+	pgStorage, err := postgres.New(&migrateCfg, log)
+	if err != nil {
+		log.Error("Error opening storage", slog.Any("error", err))
+		return 1
+	}
+	defer pgStorage.Close(log)
+
+	switch {
+	case args[0] == "up":
+		steps := 0
+		if arg != nil {
+			steps = *arg
+		}
+		err = pgStorage.MigrateUp(steps)
+	case args[0] == "down":
+		steps := 1
+		if arg != nil {
+			steps = *arg
+		}
+		err = pgStorage.MigrateDown(steps)
+	case args[0] == "goto" && arg != nil:
+		err = pgStorage.MigrateTo(uint(*arg))
+	case args[0] == "force" && arg != nil:
+		err = pgStorage.ForceVersion(*arg)
+	case args[0] == "version" && arg == nil:
+		// Printed below
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to migrate:", err)
+		return 1
+	}
+
+	return migrateVersion(pgStorage)
+}
+
+func migrateVersion(pgStorage *postgres.Storage) int {
+	version, dirty, err := pgStorage.SchemaVersion(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to get schema version:", err)
+		return 1
+	}
+
+	latest, err := postgres.LatestMigrationVersion()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to read migrations:", err)
+		return 1
+	}
+
+	if dirty {
+		fmt.Printf("Schema version %d (dirty), latest migration %d\n", version, latest)
+		return 1
+	}
+
+	fmt.Printf("Schema version %d, latest migration %d\n", version, latest)
+
+	return 0
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"song-library/internal/config"
+	"song-library/internal/http-server/certreload"
+	"syscall"
+)
+
+// newServer returns the HTTP server of the handler. It serves HTTPS with the reloaded
+// certificate if the certificate files are set, or HTTP/2 without TLS if h2c is enabled.
+func newServer(cfg *config.Config, log *slog.Logger, handler http.Handler) (*http.Server, error) {
+	server := &http.Server{
+		Addr:         cfg.Address,
+		Handler:      handler,
+		ReadTimeout:  cfg.Timeout,
+		WriteTimeout: cfg.Timeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+
+	switch {
+	case cfg.TLSCertFile != "" || cfg.TLSKeyFile != "":
+		if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+			return nil, errors.New("both TLS_CERT_FILE and TLS_KEY_FILE must be set")
+		}
+
+		reloader, err := certreload.New(cfg.TLSCertFile, cfg.TLSKeyFile, log)
+		if err != nil {
+			return nil, err
+		}
+
+		server.TLSConfig = reloader.TLSConfig()
+
+	case cfg.H2CEnabled:
+		server.Handler = h2c.NewHandler(handler, &http2.Server{IdleTimeout: cfg.IdleTimeout})
+	}
+
+	return server, nil
+}
+
+// startServer starts serving on the TCP address and on the Unix socket if it is set.
+// If the server stops serving, a signal is sent to stop.
+func startServer(cfg *config.Config, log *slog.Logger, server *http.Server, stop chan<- os.Signal) error {
+	listener, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Start server",
+		slog.String("Address", cfg.Address),
+		slog.Bool("TLS", server.TLSConfig != nil),
+		slog.Bool("h2c", server.TLSConfig == nil && cfg.H2CEnabled))
+
+	go serve(log, stop, func() error {
+		if server.TLSConfig != nil {
+			return server.ServeTLS(listener, "", "")
+		}
+
+		return server.Serve(listener)
+	})
+
+	if cfg.UnixSocket == "" {
+		return nil
+	}
+
+	// The socket file is left if the previous run was killed
+	if err = os.Remove(cfg.UnixSocket); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	unixListener, err := net.Listen("unix", cfg.UnixSocket)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Start server on Unix socket", slog.String("Socket", cfg.UnixSocket))
+
+	go serve(log, stop, func() error {
+		return server.Serve(unixListener)
+	})
+
+	return nil
+}
+
+func serve(log *slog.Logger, stop chan<- os.Signal, serveFn func() error) {
+	err := serveFn()
+
+	log.Info("Server is not running", slog.Any("reason", err))
+
+	select {
+	case stop <- syscall.SIGINT:
+	default:
+	}
+}
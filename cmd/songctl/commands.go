@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"song-library/pkg/client"
+	"strings"
+	"text/tabwriter"
+)
+
+// pageSize is the number of songs read at once by list and export
+const pageSize = 100
+
+func runAdd(ctx context.Context, c *cli) int {
+	if !c.parse(2) {
+		return 2
+	}
+
+	song := client.Song{Group: c.args[0], Song: c.args[1]}
+
+	result, err := c.client.SaveSong(ctx, song)
+	if err != nil {
+		if errors.Is(err, client.ErrAlreadyExists) {
+			fmt.Fprintf(c.stderr, "Song %q by %s already exists\n", song.Song, song.Group)
+			return 1
+		}
+
+		return c.fail("add song", err)
+	}
+
+	if c.json {
+		return c.printJSON(result)
+	}
+
+	fmt.Fprintf(c.stdout, "Song %q by %s added\n", song.Song, song.Group)
+
+	if result.EnrichJobID != 0 {
+		fmt.Fprintf(c.stdout, "Details are filled by job %d\n", result.EnrichJobID)
+	}
+
+	return 0
+}
+
+func runInfo(ctx context.Context, c *cli) int {
+	lang := c.flags.String("lang", "", "language of the text translation")
+
+	if !c.parse(2) {
+		return 2
+	}
+
+	song := client.Song{Group: c.args[0], Song: c.args[1]}
+
+	info, err := c.client.Info(ctx, song, *lang)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			fmt.Fprintf(c.stderr, "Song %q by %s not found\n", song.Song, song.Group)
+			return 1
+		}
+
+		return c.fail("get song", err)
+	}
+
+	if c.json {
+		return c.printJSON(client.SongWithDetail{Song: song, SongDetail: info.SongDetail})
+	}
+
+	tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Group:\t%s\n", song.Group)
+	fmt.Fprintf(tw, "Song:\t%s\n", song.Song)
+	fmt.Fprintf(tw, "Release date:\t%s\n", info.ReleaseDate)
+	fmt.Fprintf(tw, "Link:\t%s\n", info.Link)
+	if info.Lang != "" {
+		fmt.Fprintf(tw, "Language:\t%s\n", info.Lang)
+	}
+	_ = tw.Flush()
+
+	fmt.Fprintf(c.stdout, "\n%s\n", info.Text)
+
+	return 0
+}
+
+func runUpdate(ctx context.Context, c *cli) int {
+	date := c.flags.String("date", "", "release date dd.mm.yyyy")
+	link := c.flags.String("link", "", "link to the song")
+	text := c.flags.String("text", "", "song text")
+	textFile := c.flags.String("text-file", "", "file with the song text, - reads stdin")
+
+	if !c.parse(2) {
+		return 2
+	}
+
+	if *text != "" && *textFile != "" {
+		fmt.Fprintln(c.stderr, "Only one of -text and -text-file may be set")
+		return 2
+	}
+
+	song := client.Song{Group: c.args[0], Song: c.args[1]}
+
+	// PUT /songs replaces all details, the ones not given are kept. Details changed
+	// between the two requests, e.g. by the enrichment of a song just added, are overwritten
+	info, err := c.client.Info(ctx, song, "")
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			fmt.Fprintf(c.stderr, "Song %q by %s not found\n", song.Song, song.Group)
+			return 1
+		}
+
+		return c.fail("get song", err)
+	}
+
+	detail := info.SongDetail
+
+	if *date != "" {
+		detail.ReleaseDate = *date
+	}
+	if *link != "" {
+		detail.Link = *link
+	}
+	if *text != "" {
+		detail.Text = *text
+	}
+
+	if *textFile != "" {
+		detail.Text, err = c.readFile(*textFile)
+		if err != nil {
+			fmt.Fprintln(c.stderr, "Unable to read text file:", err)
+			return 1
+		}
+	}
+
+	err = c.client.UpdateSong(ctx, client.SongWithDetail{Song: song, SongDetail: detail})
+	if err != nil {
+		if errors.Is(err, client.ErrBadRequest) {
+			fmt.Fprintln(c.stderr, "Unable to update song: the details are incorrect, the date is dd.mm.yyyy")
+			return 1
+		}
+
+		return c.fail("update song", err)
+	}
+
+	fmt.Fprintf(c.stdout, "Song %q by %s updated\n", song.Song, song.Group)
+
+	return 0
+}
+
+func runDelete(ctx context.Context, c *cli) int {
+	if !c.parse(2) {
+		return 2
+	}
+
+	song := client.Song{Group: c.args[0], Song: c.args[1]}
+
+	err := c.client.DeleteSong(ctx, song)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			fmt.Fprintf(c.stderr, "Song %q by %s not found\n", song.Song, song.Group)
+			return 1
+		}
+
+		return c.fail("delete song", err)
+	}
+
+	fmt.Fprintf(c.stdout, "Song %q by %s deleted\n", song.Song, song.Group)
+
+	return 0
+}
+
+func runList(ctx context.Context, c *cli) int {
+	var query client.SongsQuery
+
+	c.flags.StringVar(&query.Group, "group", "", "group name")
+	c.flags.StringVar(&query.Song, "song", "", "song name")
+	c.flags.StringVar(&query.ReleaseDate, "date", "", "release date dd.mm.yyyy")
+	linkStatus := c.flags.String("link-status", "", "ok, broken, unreachable or unchecked")
+	limit := c.flags.Int("limit", 0, "max number of songs, all if zero")
+
+	if !c.parse(0) {
+		return 2
+	}
+
+	query.LinkStatus = client.LinkStatus(*linkStatus)
+
+	songs, err := c.songs(ctx, query, *limit)
+	if err != nil {
+		return c.fail("list songs", err)
+	}
+
+	if c.json {
+		return c.printJSON(songs)
+	}
+
+	tw := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "GROUP\tSONG\tRELEASED\tLINK\tLINK STATUS")
+
+	for _, song := range songs {
+		status := "-"
+		if song.LinkCheck != nil {
+			status = string(song.LinkCheck.Status)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			song.Group, song.Song.Song, dash(song.SongDetail.ReleaseDate), dash(song.SongDetail.Link), status)
+	}
+
+	_ = tw.Flush()
+
+	return 0
+}
+
+// songs reads the pages of the songs until limit songs are read, all songs if limit is zero.
+func (c *cli) songs(ctx context.Context, query client.SongsQuery, limit int) ([]client.SongWithDetail, error) {
+	songs := make([]client.SongWithDetail, 0)
+
+	query.Limit = pageSize
+
+	for query.Page = 1; ; query.Page++ {
+		page, err := c.client.Songs(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		songs = append(songs, page.Songs...)
+
+		if limit > 0 && len(songs) >= limit {
+			return songs[:limit], nil
+		}
+
+		if len(page.Songs) < pageSize {
+			return songs, nil
+		}
+	}
+}
+
+func (c *cli) printJSON(v any) int {
+	return c.writeJSON(c.stdout, v)
+}
+
+// writeJSON writes v as indented JSON to out.
+func (c *cli) writeJSON(out io.Writer, v any) int {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		fmt.Fprintln(c.stderr, "Unable to print JSON:", err)
+		return 1
+	}
+
+	return 0
+}
+
+// readFile reads the file, - is stdin.
+func (c *cli) readFile(path string) (string, error) {
+	var data []byte
+	var err error
+
+	if path == "-" {
+		data, err = io.ReadAll(c.stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
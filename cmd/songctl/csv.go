@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"song-library/pkg/client"
+)
+
+// csvHeader are the columns of imported and exported files
+var csvHeader = []string{"group", "song", "releaseDate", "text", "link"}
+
+// runImport adds the songs of the CSV file. Details in the file replace the ones filled
+// by the enrichment, songs without details in the file are only added.
+func runImport(ctx context.Context, c *cli) int {
+	if !c.parse(1) {
+		return 2
+	}
+
+	var file io.Reader = c.stdin
+
+	if c.args[0] != "-" {
+		f, err := os.Open(c.args[0])
+		if err != nil {
+			fmt.Fprintln(c.stderr, "Unable to open file:", err)
+			return 1
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		file = f
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	var added, updated, existing, failed int
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			fmt.Fprintln(c.stderr, "Unable to read file:", err)
+			return 1
+		}
+
+		if line == 1 && slices.Equal(record, csvHeader[:min(len(record), len(csvHeader))]) {
+			continue
+		}
+
+		if len(record) < 2 || len(record) > len(csvHeader) || record[0] == "" || record[1] == "" {
+			fmt.Fprintf(c.stderr, "Line %d: expected group, song and optional releaseDate, text, link\n", line)
+			failed++
+			continue
+		}
+
+		song := client.Song{Group: record[0], Song: record[1]}
+
+		_, err = c.client.SaveSong(ctx, song)
+		switch {
+		case err == nil:
+			added++
+		case errors.Is(err, client.ErrAlreadyExists):
+			existing++
+		default:
+			fmt.Fprintf(c.stderr, "Line %d: unable to add song: %v\n", line, err)
+			failed++
+			continue
+		}
+
+		record = append(record, make([]string, len(csvHeader)-len(record))...)
+
+		if record[2] == "" && record[3] == "" && record[4] == "" {
+			continue
+		}
+
+		// PUT /songs replaces all details, the ones of empty columns are kept. Details changed
+		// between the two requests, e.g. by the enrichment of a song just added, are overwritten
+		info, err := c.client.Info(ctx, song, "")
+		if err != nil {
+			fmt.Fprintf(c.stderr, "Line %d: unable to get song: %v\n", line, err)
+			failed++
+			continue
+		}
+
+		detail := info.SongDetail
+
+		if record[2] != "" {
+			detail.ReleaseDate = record[2]
+		}
+		if record[3] != "" {
+			detail.Text = record[3]
+		}
+		if record[4] != "" {
+			detail.Link = record[4]
+		}
+
+		err = c.client.UpdateSong(ctx, client.SongWithDetail{Song: song, SongDetail: detail})
+		if err != nil {
+			fmt.Fprintf(c.stderr, "Line %d: unable to update song: %v\n", line, err)
+			failed++
+			continue
+		}
+
+		updated++
+	}
+
+	fmt.Fprintf(c.stdout, "Added %d, updated %d, already existing %d, failed %d\n", added, updated, existing, failed)
+
+	if failed > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// runExport writes all songs as CSV with the header row, the file can be imported back,
+// or as JSON with -json.
+func runExport(ctx context.Context, c *cli) int {
+	output := c.flags.String("o", "", "output file, stdout if not set")
+
+	if !c.parse(0) {
+		return 2
+	}
+
+	out := c.stdout
+
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(c.stderr, "Unable to create file:", err)
+			return 1
+		}
+
+		defer func() {
+			_ = f.Close()
+		}()
+
+		out = f
+	}
+
+	songs, err := c.songs(ctx, client.SongsQuery{}, 0)
+	if err != nil {
+		return c.fail("list songs", err)
+	}
+
+	if c.json {
+		return c.writeJSON(out, songs)
+	}
+
+	writer := csv.NewWriter(out)
+	_ = writer.Write(csvHeader)
+
+	for _, song := range songs {
+		_ = writer.Write([]string{song.Group, song.Song.Song, song.SongDetail.ReleaseDate, song.SongDetail.Text, song.SongDetail.Link})
+	}
+
+	writer.Flush()
+
+	if err = writer.Error(); err != nil {
+		fmt.Fprintln(c.stderr, "Unable to write songs:", err)
+		return 1
+	}
+
+	return 0
+}
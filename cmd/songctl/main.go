@@ -0,0 +1,200 @@
+// Command songctl manages the song library through its REST API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"song-library/pkg/client"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const usage = `Usage:
+  songctl add <group> <song>                 Add a song, its details are filled by the enrichment
+  songctl info <group> <song> [-lang de]     Show the song details
+  songctl update <group> <song> [-date dd.mm.yyyy] [-link URL] [-text TEXT | -text-file FILE]
+                                             Change the song details, others are kept
+  songctl delete <group> <song>              Delete the song
+  songctl list [-group G] [-song S] [-link-status broken] [-limit N]
+                                             List the songs, all of them without -limit
+  songctl import <file.csv>                  Add the songs of the CSV file, - reads stdin
+  songctl export [-o file.csv]               Write all songs as CSV, or JSON with -json
+
+Flags of every command:
+  -url URL        server URL, SONGCTL_URL, default http://localhost:8080
+  -api-key KEY    API key, SONGCTL_API_KEY
+  -token JWT      bearer token, SONGCTL_TOKEN, used if the API key is not set
+  -timeout 30s    timeout of a request, SONGCTL_TIMEOUT
+  -retries 2      retries of failed requests, SONGCTL_RETRIES
+  -json           print JSON instead of a table
+
+CSV files have the columns group, song, releaseDate, text and link, the header row is optional.
+update and import read the song and then replace its details, so details changed in between,
+e.g. by the enrichment of a song just added, are overwritten.`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr, os.Getenv))
+}
+
+// env is the part of the environment songctl reads, os.Getenv in main.
+type env func(key string) string
+
+// cli is the state of a command: its flags, output and the API client.
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	flags *flag.FlagSet
+	// args are the positional arguments, flags may be given before and after them
+	args []string
+
+	url     string
+	apiKey  string
+	token   string
+	timeout time.Duration
+	retries int
+	json    bool
+
+	client *client.Client
+}
+
+// run runs the command of the arguments and returns the exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer, getenv env) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	commands := map[string]func(ctx context.Context, c *cli) int{
+		"add":    runAdd,
+		"info":   runInfo,
+		"update": runUpdate,
+		"delete": runDelete,
+		"list":   runList,
+		"import": runImport,
+		"export": runExport,
+	}
+
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	c := &cli{
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		flags:  flag.NewFlagSet("songctl "+args[0], flag.ContinueOnError),
+	}
+
+	c.flags.SetOutput(stderr)
+	c.flags.Usage = func() {
+		fmt.Fprintln(stderr, usage)
+	}
+
+	timeout, err := time.ParseDuration(withDefault(getenv("SONGCTL_TIMEOUT"), "30s"))
+	if err != nil {
+		fmt.Fprintln(stderr, "Incorrect SONGCTL_TIMEOUT:", err)
+		return 2
+	}
+
+	retries, err := strconv.Atoi(withDefault(getenv("SONGCTL_RETRIES"), "2"))
+	if err != nil {
+		fmt.Fprintln(stderr, "Incorrect SONGCTL_RETRIES:", err)
+		return 2
+	}
+
+	c.flags.StringVar(&c.url, "url", withDefault(getenv("SONGCTL_URL"), "http://localhost:8080"), "server URL")
+	c.flags.StringVar(&c.apiKey, "api-key", getenv("SONGCTL_API_KEY"), "API key")
+	c.flags.StringVar(&c.token, "token", getenv("SONGCTL_TOKEN"), "bearer token")
+	c.flags.DurationVar(&c.timeout, "timeout", timeout, "timeout of a request")
+	c.flags.IntVar(&c.retries, "retries", retries, "retries of failed requests")
+	c.flags.BoolVar(&c.json, "json", false, "print JSON")
+
+	c.args = args[1:]
+
+	return command(ctx, c)
+}
+
+// parse parses the flags of the command, which the command defines before,
+// and checks the number of the positional arguments.
+func (c *cli) parse(positional int) bool {
+	args := c.args
+	c.args = nil
+
+	// flag stops at the first positional argument, the rest is parsed again
+	for {
+		if err := c.flags.Parse(args); err != nil {
+			return false
+		}
+
+		if c.flags.NArg() == 0 {
+			break
+		}
+
+		c.args = append(c.args, c.flags.Arg(0))
+		args = c.flags.Args()[1:]
+	}
+
+	if len(c.args) != positional {
+		fmt.Fprintln(c.stderr, usage)
+		return false
+	}
+
+	opts := []client.Option{
+		client.WithHTTPClient(&http.Client{Timeout: c.timeout}),
+		client.WithRetries(c.retries, 500*time.Millisecond),
+		client.WithUserAgent("songctl"),
+	}
+
+	switch {
+	case c.apiKey != "":
+		opts = append(opts, client.WithAPIKey(c.apiKey))
+	case c.token != "":
+		opts = append(opts, client.WithBearerToken(c.token))
+	}
+
+	var err error
+
+	c.client, err = client.New(c.url, opts...)
+	if err != nil {
+		fmt.Fprintln(c.stderr, err)
+		return false
+	}
+
+	return true
+}
+
+// fail prints the error of the API call and returns the exit code.
+func (c *cli) fail(action string, err error) int {
+	switch {
+	case errors.Is(err, client.ErrUnauthorized):
+		fmt.Fprintf(c.stderr, "Unable to %s: credentials are missing or invalid, set SONGCTL_API_KEY or SONGCTL_TOKEN\n", action)
+	case errors.Is(err, client.ErrForbidden):
+		fmt.Fprintf(c.stderr, "Unable to %s: the role of the credentials is not allowed\n", action)
+	default:
+		fmt.Fprintf(c.stderr, "Unable to %s: %v\n", action, err)
+	}
+
+	return 1
+}
+
+func withDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
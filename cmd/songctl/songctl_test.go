@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"song-library/pkg/client"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// library is a fake server keeping the songs in memory.
+type library struct {
+	mu    sync.Mutex
+	songs []client.SongWithDetail
+}
+
+func (l *library) find(song client.Song) int {
+	for i, s := range l.songs {
+		if s.Song == song {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (l *library) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	query := r.URL.Query()
+
+	switch r.Method + " " + r.URL.Path {
+	case "GET /songs":
+		page, _ := strconv.Atoi(query.Get("page"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		songs := make([]client.SongWithDetail, 0)
+		for _, s := range l.songs {
+			if group := query.Get("group"); group == "" || group == s.Group {
+				songs = append(songs, s)
+			}
+		}
+
+		from := min((page-1)*limit, len(songs))
+		songs = songs[from:min(from+limit, len(songs))]
+
+		if len(songs) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(client.SongsPage{Songs: songs, Page: page, Limit: limit, Items: len(songs)})
+	case "GET /info":
+		i := l.find(client.Song{Group: query.Get("group"), Song: query.Get("song")})
+		if i < 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(l.songs[i].SongDetail)
+	case "POST /songs":
+		var song client.Song
+		_ = json.NewDecoder(r.Body).Decode(&song)
+
+		if l.find(song) >= 0 {
+			w.WriteHeader(http.StatusAlreadyReported)
+			return
+		}
+
+		l.songs = append(l.songs, client.SongWithDetail{Song: song})
+		w.WriteHeader(http.StatusCreated)
+	case "PUT /songs":
+		var song client.SongWithDetail
+		_ = json.NewDecoder(r.Body).Decode(&song)
+
+		i := l.find(song.Song)
+		if i < 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		l.songs[i] = song
+	case "DELETE /songs":
+		var song client.Song
+		_ = json.NewDecoder(r.Body).Decode(&song)
+
+		i := l.find(song)
+		if i < 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		l.songs = append(l.songs[:i], l.songs[i+1:]...)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func runTest(t *testing.T, server *httptest.Server, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	getenv := func(key string) string {
+		if key == "SONGCTL_URL" {
+			return server.URL
+		}
+
+		return ""
+	}
+
+	code := run(context.Background(), args, strings.NewReader(stdin), &stdout, &stderr, getenv)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func newTestServer(t *testing.T, songs ...client.SongWithDetail) (*library, *httptest.Server) {
+	t.Helper()
+
+	lib := &library{songs: songs}
+
+	server := httptest.NewServer(lib)
+	t.Cleanup(server.Close)
+
+	return lib, server
+}
+
+func TestUsage(t *testing.T) {
+	_, server := newTestServer(t)
+
+	for _, args := range [][]string{nil, {"unknown"}, {"add", "Muse"}, {"list", "extra"}} {
+		code, _, stderr := runTest(t, server, "", args...)
+		require.Equal(t, 2, code, args)
+		require.Contains(t, stderr, "Usage:", args)
+	}
+}
+
+func TestAddDelete(t *testing.T) {
+	lib, server := newTestServer(t)
+
+	code, stdout, _ := runTest(t, server, "", "add", "Muse", "Uprising")
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout, `Song "Uprising" by Muse added`)
+
+	code, _, stderr := runTest(t, server, "", "add", "Muse", "Uprising")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "already exists")
+
+	code, _, _ = runTest(t, server, "", "delete", "Muse", "Uprising")
+	require.Equal(t, 0, code)
+	require.Empty(t, lib.songs)
+
+	code, _, stderr = runTest(t, server, "", "delete", "Muse", "Uprising")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "not found")
+}
+
+func TestUpdateTextFile(t *testing.T) {
+	lib, server := newTestServer(t, client.SongWithDetail{
+		Song:       client.Song{Group: "Muse", Song: "Uprising"},
+		SongDetail: client.SongDetail{ReleaseDate: "16.07.2009", Link: "https://example.com", Text: "Old"},
+	})
+
+	path := filepath.Join(t.TempDir(), "lyrics.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Paranoia is in bloom\n"), 0o600))
+
+	// Flags may follow the positional arguments
+	code, _, stderr := runTest(t, server, "", "update", "Muse", "Uprising", "--text-file", path)
+	require.Equal(t, 0, code, stderr)
+
+	require.Equal(t, client.SongDetail{
+		ReleaseDate: "16.07.2009",
+		Link:        "https://example.com",
+		Text:        "Paranoia is in bloom",
+	}, lib.songs[0].SongDetail)
+
+	code, _, _ = runTest(t, server, "Stdin text", "update", "-date", "17.07.2009", "-text-file", "-", "Muse", "Uprising")
+	require.Equal(t, 0, code)
+	require.Equal(t, "17.07.2009", lib.songs[0].SongDetail.ReleaseDate)
+	require.Equal(t, "Stdin text", lib.songs[0].SongDetail.Text)
+
+	code, _, stderr = runTest(t, server, "", "update", "Muse", "Missing", "-link", "https://example.com")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "not found")
+}
+
+func TestList(t *testing.T) {
+	var songs []client.SongWithDetail
+
+	// More than a page to read all pages
+	for i := range pageSize + 5 {
+		songs = append(songs, client.SongWithDetail{Song: client.Song{Group: "Muse", Song: "Song " + strconv.Itoa(i)}})
+	}
+
+	songs = append(songs, client.SongWithDetail{
+		Song:       client.Song{Group: "Queen", Song: "Bohemian Rhapsody"},
+		SongDetail: client.SongDetail{ReleaseDate: "31.10.1975"},
+		LinkCheck:  &client.LinkCheck{Status: client.LinkOK},
+	})
+
+	_, server := newTestServer(t, songs...)
+
+	code, stdout, _ := runTest(t, server, "", "list", "--group", "Muse", "--json")
+	require.Equal(t, 0, code)
+
+	var listed []client.SongWithDetail
+	require.NoError(t, json.Unmarshal([]byte(stdout), &listed))
+	require.Equal(t, songs[:pageSize+5], listed)
+
+	code, stdout, _ = runTest(t, server, "", "list", "-limit", "3")
+	require.Equal(t, 0, code)
+	require.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 4)
+
+	code, stdout, _ = runTest(t, server, "", "list", "-group", "Queen")
+	require.Equal(t, 0, code)
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, []string{"GROUP", "SONG", "RELEASED", "LINK", "LINK", "STATUS"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"Queen", "Bohemian", "Rhapsody", "31.10.1975", "-", "ok"}, strings.Fields(lines[1]))
+}
+
+func TestImportExport(t *testing.T) {
+	lib, server := newTestServer(t, client.SongWithDetail{
+		Song:       client.Song{Group: "Muse", Song: "Uprising"},
+		SongDetail: client.SongDetail{ReleaseDate: "16.07.2009"},
+	})
+
+	input := `group,song,releaseDate,text,link
+Muse,Uprising,,"Paranoia is in bloom,
+The PR transmissions will resume",https://example.com
+Queen,Bohemian Rhapsody
+Muse
+`
+
+	code, stdout, stderr := runTest(t, server, input, "import", "-")
+	require.Equal(t, 1, code)
+	require.Equal(t, "Added 1, updated 1, already existing 1, failed 1\n", stdout)
+	require.Contains(t, stderr, "Line 4:")
+
+	require.Len(t, lib.songs, 2)
+	require.Equal(t, "Paranoia is in bloom,\nThe PR transmissions will resume", lib.songs[0].SongDetail.Text)
+	// The release date of the empty column is kept
+	require.Equal(t, "16.07.2009", lib.songs[0].SongDetail.ReleaseDate)
+
+	path := filepath.Join(t.TempDir(), "songs.csv")
+
+	code, _, _ = runTest(t, server, "", "export", "-o", path)
+	require.Equal(t, 0, code)
+
+	exported, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `group,song,releaseDate,text,link
+Muse,Uprising,16.07.2009,"Paranoia is in bloom,
+The PR transmissions will resume",https://example.com
+Queen,Bohemian Rhapsody,,,
+`, string(exported))
+
+	// JSON is written to the file too
+	jsonPath := filepath.Join(t.TempDir(), "songs.json")
+
+	code, stdout, _ = runTest(t, server, "", "export", "-json", "-o", jsonPath)
+	require.Equal(t, 0, code)
+	require.Empty(t, stdout)
+
+	exportedJSON, err := os.ReadFile(jsonPath)
+	require.NoError(t, err)
+
+	var songs []client.SongWithDetail
+	require.NoError(t, json.Unmarshal(exportedJSON, &songs))
+	require.Equal(t, lib.songs, songs)
+
+	// The export is imported back without changes
+	_, server = newTestServer(t)
+
+	code, stdout, _ = runTest(t, server, string(exported), "import", "-")
+	require.Equal(t, 0, code)
+	require.Equal(t, "Added 2, updated 1, already existing 0, failed 0\n", stdout)
+}
@@ -0,0 +1,34 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// prefix makes the keys easy to recognize, e.g. by secret scanners.
+const prefix = "sl_"
+
+// keyLength is the number of random bytes in the key.
+const keyLength = 32
+
+// Generate returns a new random API key and its hash.
+// Only the hash is stored, the key is shown to the user once.
+func Generate() (key string, hash string, err error) {
+	buf := make([]byte, keyLength)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	key = prefix + base64.RawURLEncoding.EncodeToString(buf)
+
+	return key, Hash(key), nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of the API key.
+// Keys have 256 bits of entropy, so a fast hash without salt is sufficient.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,61 @@
+package auth
+
+import "strconv"
+
+// Role grants permissions to the routes.
+// Roles are ordered: every role has all permissions of the previous ones.
+type Role string
+
+const (
+	RoleReader Role = "reader"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// rank returns the position of the role, 0 for unknown roles.
+func (r Role) rank() int {
+	switch r {
+	case RoleReader:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// Principal is the authenticated client.
+type Principal struct {
+	// Subject identifies the client in logs and rate limits, e.g. "apikey:3:importer" or JWT 'sub' claim
+	Subject string
+	// APIKeyID is the id of the API key, zero for JWT
+	APIKeyID int
+	// Role is the highest role of the client, empty if the client has no known roles
+	Role Role
+}
+
+// APIKeyPrincipal returns the principal of the API key. Key names are not unique,
+// so the subject has the key id to tell the clients apart.
+func APIKeyPrincipal(keyID int, name string) Principal {
+	return Principal{Subject: "apikey:" + strconv.Itoa(keyID) + ":" + name, APIKeyID: keyID, Role: RoleAdmin}
+}
+
+// Has reports whether the principal has permissions of the role.
+func (p Principal) Has(role Role) bool {
+	return p.Role.rank() > 0 && p.Role.rank() >= role.rank()
+}
+
+// HighestRole returns the highest known role from the list, unknown roles are ignored.
+func HighestRole(roles []string) Role {
+	var highest Role
+
+	for _, role := range roles {
+		if Role(role).rank() > highest.rank() {
+			highest = Role(role)
+		}
+	}
+
+	return highest
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"song-library/internal/config"
+	"strings"
+)
+
+var (
+	ErrJWTDisabled = errors.New("jwt authentication is not configured")
+	ErrUnknownKey  = errors.New("unknown signing key")
+	// ErrNoSubject is returned for tokens without 'sub' claim, the subject identifies the client
+	ErrNoSubject = errors.New("token has no subject")
+)
+
+// JWTVerifier verifies JWTs signed with HMAC secret (HS256/384/512)
+// or with RSA keys from JWKS file (RS256/384/512).
+type JWTVerifier struct {
+	hmacSecret []byte
+	rsaKeys    map[string]*rsa.PublicKey
+	issuer     string
+	audience   string
+	rolesClaim string
+}
+
+func NewJWTVerifier(cfg *config.Config) (*JWTVerifier, error) {
+	const op = "auth.NewJWTVerifier"
+
+	verifier := &JWTVerifier{
+		hmacSecret: []byte(cfg.JwtHmacSecret),
+		issuer:     cfg.JwtIssuer,
+		audience:   cfg.JwtAudience,
+		rolesClaim: cfg.JwtRolesClaim,
+	}
+
+	if cfg.JwtJwksFile != "" {
+		keys, err := LoadJWKS(cfg.JwtJwksFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		verifier.rsaKeys = keys
+	}
+
+	return verifier, nil
+}
+
+// Enabled reports whether any signing key is configured.
+func (v *JWTVerifier) Enabled() bool {
+	return len(v.hmacSecret) > 0 || len(v.rsaKeys) > 0
+}
+
+// Verify validates the token signature and standard claims
+// and maps the roles claim to the principal role.
+func (v *JWTVerifier) Verify(tokenString string) (Principal, error) {
+	if !v.Enabled() {
+		return Principal{}, ErrJWTDisabled
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(v.validMethods()),
+		jwt.WithExpirationRequired(),
+	}
+	if v.issuer != "" {
+		options = append(options, jwt.WithIssuer(v.issuer))
+	}
+	if v.audience != "" {
+		options = append(options, jwt.WithAudience(v.audience))
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenString, claims, v.key, options...)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if subject == "" {
+		return Principal{}, ErrNoSubject
+	}
+
+	return Principal{
+		Subject: subject,
+		Role:    HighestRole(rolesFromClaim(claims[v.rolesClaim])),
+	}, nil
+}
+
+func (v *JWTVerifier) validMethods() []string {
+	methods := make([]string, 0, 6)
+
+	if len(v.hmacSecret) > 0 {
+		methods = append(methods, "HS256", "HS384", "HS512")
+	}
+	if len(v.rsaKeys) > 0 {
+		methods = append(methods, "RS256", "RS384", "RS512")
+	}
+
+	return methods
+}
+
+// key returns the verification key for the token.
+// The algorithm is already checked by the parser against validMethods.
+func (v *JWTVerifier) key(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		return v.hmacSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := v.rsaKeys[kid]; ok {
+		return key, nil
+	}
+
+	// A token without 'kid' is accepted if there is only one key
+	if kid == "" && len(v.rsaKeys) == 1 {
+		for _, key := range v.rsaKeys {
+			return key, nil
+		}
+	}
+
+	return nil, ErrUnknownKey
+}
+
+// rolesFromClaim accepts roles as a JSON array or as a space separated string.
+func rolesFromClaim(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		roles := make([]string, 0, len(value))
+		for _, role := range value {
+			if roleString, ok := role.(string); ok {
+				roles = append(roles, roleString)
+			}
+		}
+		return roles
+	default:
+		return nil
+	}
+}
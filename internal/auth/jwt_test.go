@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"os"
+	"path/filepath"
+	"song-library/internal/config"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret"
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+
+	set := jwks{Keys: []jwk{{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func TestJWTVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	verifier, err := NewJWTVerifier(&config.Config{
+		JwtHmacSecret: testSecret,
+		JwtJwksFile:   writeJWKS(t, "key-1", &rsaKey.PublicKey),
+		JwtIssuer:     "https://auth.example.com",
+		JwtRolesClaim: "roles",
+	})
+	require.NoError(t, err)
+
+	validClaims := func(roles interface{}) jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "alice",
+			"iss":   "https://auth.example.com",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": roles,
+		}
+	}
+
+	sign := func(method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	cases := []struct {
+		name    string
+		token   string
+		role    Role
+		wantErr bool
+	}{
+		{
+			name:  "HMAC",
+			token: sign(jwt.SigningMethodHS256, "", []byte(testSecret), validClaims([]string{"editor"})),
+			role:  RoleEditor,
+		},
+		{
+			name:  "RSA",
+			token: sign(jwt.SigningMethodRS256, "key-1", rsaKey, validClaims([]string{"reader", "admin"})),
+			role:  RoleAdmin,
+		},
+		{
+			name:  "Roles as string",
+			token: sign(jwt.SigningMethodHS256, "", []byte(testSecret), validClaims("reader editor")),
+			role:  RoleEditor,
+		},
+		{
+			name:  "Unknown roles",
+			token: sign(jwt.SigningMethodHS256, "", []byte(testSecret), validClaims([]string{"superuser"})),
+			role:  "",
+		},
+		{
+			name:    "Wrong HMAC secret",
+			token:   sign(jwt.SigningMethodHS256, "", []byte("wrong"), validClaims([]string{"admin"})),
+			wantErr: true,
+		},
+		{
+			name:    "Unknown RSA key",
+			token:   sign(jwt.SigningMethodRS256, "key-2", otherKey, validClaims([]string{"admin"})),
+			wantErr: true,
+		},
+		{
+			name: "Expired",
+			token: sign(jwt.SigningMethodHS256, "", []byte(testSecret), jwt.MapClaims{
+				"sub": "alice",
+				"iss": "https://auth.example.com",
+				"exp": time.Now().Add(-time.Hour).Unix(),
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Without expiration",
+			token: sign(jwt.SigningMethodHS256, "", []byte(testSecret), jwt.MapClaims{
+				"sub": "alice",
+				"iss": "https://auth.example.com",
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Wrong issuer",
+			token: sign(jwt.SigningMethodHS256, "", []byte(testSecret), jwt.MapClaims{
+				"sub": "alice",
+				"iss": "https://evil.example.com",
+				"exp": time.Now().Add(time.Hour).Unix(),
+			}),
+			wantErr: true,
+		},
+		{
+			name: "Without subject",
+			token: sign(jwt.SigningMethodHS256, "", []byte(testSecret), jwt.MapClaims{
+				"iss": "https://auth.example.com",
+				"exp": time.Now().Add(time.Hour).Unix(),
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "Unsigned",
+			token:   sign(jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, validClaims([]string{"admin"})),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			principal, err := verifier.Verify(tc.token)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "alice", principal.Subject)
+			require.Equal(t, tc.role, principal.Role)
+		})
+	}
+}
+
+func TestJWTVerifier_Disabled(t *testing.T) {
+	verifier, err := NewJWTVerifier(&config.Config{})
+	require.NoError(t, err)
+
+	require.False(t, verifier.Enabled())
+
+	_, err = verifier.Verify("any.token.value")
+	require.ErrorIs(t, err, ErrJWTDisabled)
+}
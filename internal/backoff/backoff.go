@@ -0,0 +1,15 @@
+package backoff
+
+import "time"
+
+// Exponential returns the delay before the next attempt: the base delay
+// doubled after each failed attempt up to the max delay.
+func Exponential(base time.Duration, max time.Duration, attempts int) time.Duration {
+	delay := base
+
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+
+	return min(delay, max)
+}
@@ -0,0 +1,14 @@
+package backoff
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestExponential(t *testing.T) {
+	require.Equal(t, time.Second, Exponential(time.Second, 3*time.Second, 1))
+	require.Equal(t, 2*time.Second, Exponential(time.Second, 3*time.Second, 2))
+	require.Equal(t, 3*time.Second, Exponential(time.Second, 3*time.Second, 3))
+	require.Equal(t, 3*time.Second, Exponential(time.Second, 3*time.Second, 100))
+}
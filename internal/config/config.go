@@ -1,12 +1,21 @@
 package config
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"github.com/caarlos0/env/v9"
 	"github.com/joho/godotenv"
+	"io/fs"
 	"log"
+	"maps"
+	"os"
+	"strings"
 	"time"
 )
 
+// Config is the server configuration. Every field is set by the variable named in the env tag,
+// fields with the secret tag are redacted when the config is printed.
 type Config struct {
 	// Environment: local, dev, test, prod
 	Environment string `env:"ENVIRONMENT" envDefault:"local"`
@@ -14,25 +23,175 @@ type Config struct {
 	Address     string        `env:"ADDRESS" envDefault:"localhost:8080"` // example.com:8080
 	Timeout     time.Duration `env:"TIMEOUT" envDefault:"5s"`
 	IdleTimeout time.Duration `env:"IDLE_TIMEOUT" envDefault:"60s"`
+	// HTTPS is served if the certificate and key files are set,
+	// the certificate is reloaded without restart when the files change
+	TLSCertFile string `env:"TLS_CERT_FILE"`
+	TLSKeyFile  string `env:"TLS_KEY_FILE"`
+	// Serve HTTP/2 without TLS (h2c) for internal traffic, ignored if HTTPS is served
+	H2CEnabled bool `env:"H2C_ENABLED" envDefault:"false"`
+	// Also serve plain HTTP on the Unix domain socket, e.g. for a sidecar proxy
+	UnixSocket string `env:"UNIX_SOCKET"`
+	// gRPC API server on its own port, served with TLS if HTTPS is served, empty address disables it
+	GrpcAddress string `env:"GRPC_ADDRESS" envDefault:"localhost:9090"`
+	// Responses smaller than the size in bytes are not compressed
+	CompressMinSize int `env:"COMPRESS_MIN_SIZE" envDefault:"1024"`
+	// max-age of the read endpoints, with zero clients revalidate every request
+	CacheMaxAge time.Duration `env:"CACHE_MAX_AGE" envDefault:"0s"`
+	// Time between failing the readiness probe and stopping the server on shutdown
+	ShutdownDrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"5s"`
 	// Postgres connection
 	PgHost     string `env:"PG_HOST" envDefault:"localhost"`
 	PgPort     string `env:"PG_PORT" envDefault:"5432"`
 	PgUser     string `env:"PG_USER" envDefault:"postgres"`
-	PgPass     string `env:"PG_PASS" envDefault:"postgres"`
+	PgPass     string `env:"PG_PASS" envDefault:"postgres" secret:"true"`
 	PgDatabase string `env:"PG_DATABASE" envDefault:"songs"`
+	// TLS: sslmode disable, require, verify-ca or verify-full and certificate files
+	PgSSLMode     string `env:"PG_SSLMODE" envDefault:"disable"`
+	PgSSLRootCert string `env:"PG_SSLROOTCERT"`
+	PgSSLCert     string `env:"PG_SSLCERT"`
+	PgSSLKey      string `env:"PG_SSLKEY"`
+	// Full connection URL, overrides the PG_* connection settings above
+	DatabaseURL string `env:"DATABASE_URL" secret:"true"`
+	// Connection pool
+	PgMaxOpenConns    int           `env:"PG_MAX_OPEN_CONNS" envDefault:"25"`
+	PgMaxIdleConns    int           `env:"PG_MAX_IDLE_CONNS" envDefault:"10"`
+	PgConnMaxLifetime time.Duration `env:"PG_CONN_MAX_LIFETIME" envDefault:"30m"`
+	// Apply pending migrations on startup, otherwise they are applied by the 'migrate' command
+	// and the readiness probe fails until the schema is up to date
+	AutoMigrate bool `env:"AUTO_MIGRATE" envDefault:"true"`
+	// How long to wait for the database to become available on startup
+	PgConnectTimeout time.Duration `env:"PG_CONNECT_TIMEOUT" envDefault:"30s"`
+	// Maximum duration of a storage operation, zero disables the limit
+	DbQueryTimeout time.Duration `env:"DB_QUERY_TIMEOUT" envDefault:"3s"`
+	// Music info service the details of new songs are fetched from, empty URL disables enrichment
+	MusicInfoURL          string        `env:"MUSICINFO_URL"`
+	MusicInfoTimeout      time.Duration `env:"MUSICINFO_TIMEOUT" envDefault:"1s"`
+	MusicInfoRetries      int           `env:"MUSICINFO_RETRIES" envDefault:"2"`
+	MusicInfoRetryBackoff time.Duration `env:"MUSICINFO_RETRY_BACKOFF" envDefault:"100ms"`
+	// The circuit breaker opens for the cooldown after the number of failed requests in a row
+	MusicInfoBreakerThreshold int           `env:"MUSICINFO_BREAKER_THRESHOLD" envDefault:"5"`
+	MusicInfoBreakerCooldown  time.Duration `env:"MUSICINFO_BREAKER_COOLDOWN" envDefault:"30s"`
+	// Background jobs: number of workers, polling of the jobs table and retries with exponential backoff,
+	// a job is dead after max attempts
+	JobWorkers         int           `env:"JOB_WORKERS" envDefault:"4"`
+	JobPollInterval    time.Duration `env:"JOB_POLL_INTERVAL" envDefault:"1s"`
+	JobMaxAttempts     int           `env:"JOB_MAX_ATTEMPTS" envDefault:"5"`
+	JobRetryBackoff    time.Duration `env:"JOB_RETRY_BACKOFF" envDefault:"5s"`
+	JobRetryMaxBackoff time.Duration `env:"JOB_RETRY_MAX_BACKOFF" envDefault:"10m"`
+	// Maximum duration of a job attempt, a job not finished in time is run again by another worker
+	JobTimeout time.Duration `env:"JOB_TIMEOUT" envDefault:"5m"`
+	// How long in-flight jobs are waited for on shutdown
+	JobShutdownTimeout time.Duration `env:"JOB_SHUTDOWN_TIMEOUT" envDefault:"30s"`
+	// Dead link checker: links are checked again after max age, zero interval disables the checker
+	LinkCheckInterval    time.Duration `env:"LINK_CHECK_INTERVAL" envDefault:"1h"`
+	LinkCheckMaxAge      time.Duration `env:"LINK_CHECK_MAX_AGE" envDefault:"24h"`
+	LinkCheckConcurrency int           `env:"LINK_CHECK_CONCURRENCY" envDefault:"4"`
+	LinkCheckTimeout     time.Duration `env:"LINK_CHECK_TIMEOUT" envDefault:"10s"`
+	LinkCheckBatchSize   int           `env:"LINK_CHECK_BATCH_SIZE" envDefault:"100"`
+	// Timeout of a webhook delivery attempt, failed deliveries are retried by the job queue
+	WebhookTimeout time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
+	// Change feed: interval of SSE comments keeping idle streams open and how long events are kept
+	// for clients resuming from Last-Event-ID, zero retention keeps them forever
+	EventsHeartbeat time.Duration `env:"EVENTS_HEARTBEAT" envDefault:"15s"`
+	EventsRetention time.Duration `env:"EVENTS_RETENTION" envDefault:"168h"`
+	// Outbox relay: sink of the song events (stdout, file or http), with an empty sink the events only drive the webhooks.
+	// Failed events are retried with exponential backoff, published ones are kept for the retention
+	OutboxSink            string        `env:"OUTBOX_SINK"`
+	OutboxFile            string        `env:"OUTBOX_FILE"`
+	OutboxURL             string        `env:"OUTBOX_URL"`
+	OutboxPollInterval    time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"1s"`
+	OutboxBatchSize       int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	OutboxTimeout         time.Duration `env:"OUTBOX_TIMEOUT" envDefault:"10s"`
+	OutboxRetryBackoff    time.Duration `env:"OUTBOX_RETRY_BACKOFF" envDefault:"1s"`
+	OutboxRetryMaxBackoff time.Duration `env:"OUTBOX_RETRY_MAX_BACKOFF" envDefault:"5m"`
+	OutboxRetention       time.Duration `env:"OUTBOX_RETENTION" envDefault:"168h"`
+	// Authentication
+	// GET endpoints are public unless AuthPublicRead is false
+	AuthPublicRead bool `env:"AUTH_PUBLIC_READ" envDefault:"true"`
+	// JWT bearer tokens signed with HMAC secret and/or RSA keys from JWKS file
+	JwtHmacSecret string `env:"JWT_HMAC_SECRET" secret:"true"`
+	JwtJwksFile   string `env:"JWT_JWKS_FILE"`
+	JwtIssuer     string `env:"JWT_ISSUER"`
+	JwtAudience   string `env:"JWT_AUDIENCE"`
+	JwtRolesClaim string `env:"JWT_ROLES_CLAIM" envDefault:"roles"`
+	// Rate limits per client: requests per second and burst size, zero rate disables the limit.
+	// The IP limit applies to all requests before authentication, so invalid credentials are limited too.
+	RateLimitIPRPS      float64 `env:"RATE_LIMIT_IP_RPS" envDefault:"50"`
+	RateLimitIPBurst    int     `env:"RATE_LIMIT_IP_BURST" envDefault:"100"`
+	RateLimitReadRPS    float64 `env:"RATE_LIMIT_READ_RPS" envDefault:"20"`
+	RateLimitReadBurst  int     `env:"RATE_LIMIT_READ_BURST" envDefault:"40"`
+	RateLimitWriteRPS   float64 `env:"RATE_LIMIT_WRITE_RPS" envDefault:"5"`
+	RateLimitWriteBurst int     `env:"RATE_LIMIT_WRITE_BURST" envDefault:"10"`
+	// OpenTelemetry tracing, OTLP exporter is configured by OTEL_EXPORTER_OTLP_* variables
+	TracingEnabled     bool    `env:"TRACING_ENABLED" envDefault:"false"`
+	TracingServiceName string  `env:"OTEL_SERVICE_NAME" envDefault:"song-library"`
+	TracingSampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
 }
 
-func MustLoad() *Config {
-	err := godotenv.Load()
+// MustLoad loads the config from the command line arguments and exits on errors.
+// It returns the arguments left after the flags, i.e. the command.
+func MustLoad(args []string) (*Config, []string) {
+	cfg, rest, err := Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+
+	return cfg, rest
+}
+
+// Load loads the config in layers, each layer overrides the previous ones:
+//
+//	defaults < YAML file < environment (and .env file) < command line flags
+//
+// The YAML file is set by the -config flag or the CONFIG_FILE variable.
+// The loaded config is validated, all invalid values are reported at once.
+func Load(args []string) (*Config, []string, error) {
+	flags, err := parseFlags(args)
 	if err != nil {
-		log.Fatalf("Unable to load .env file: %e", err)
+		return nil, nil, err
+	}
+
+	// .env is optional, its variables do not override the real environment
+	if err = godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("unable to load .env file: %w", err)
 	}
 
+	environment := make(map[string]string)
+
+	configFile := flags.configFile
+	if configFile == "" {
+		configFile = os.Getenv("CONFIG_FILE")
+	}
+
+	if configFile != "" {
+		values, err := readYAML(configFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		maps.Copy(environment, values)
+	}
+
+	for _, kv := range os.Environ() {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			environment[key] = value
+		}
+	}
+
+	maps.Copy(environment, flags.values)
+
 	cfg := Config{}
-	err = env.Parse(&cfg)
+	err = env.ParseWithOptions(&cfg, env.Options{Environment: environment})
 	if err != nil {
-		log.Fatalf("Unable to parse ennvironment variables: %e", err)
+		return nil, nil, err
+	}
+
+	if err = cfg.Validate(); err != nil {
+		return nil, nil, err
 	}
 
-	return &cfg
+	return &cfg, flags.args, nil
 }
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadLayers(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configFile, []byte("pg_host: yaml.local\npg-port: 6543\nTIMEOUT: 7s\nauth_public_read: false\n"), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("CONFIG_FILE", configFile)
+	t.Setenv("PG_PORT", "7777")
+	t.Setenv("PG_USER", "env")
+
+	cfg, args, err := Load([]string{"-pg-user", "flag", "-idle-timeout=2m", "apikey", "list"})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"apikey", "list"}, args)
+	// Default
+	require.Equal(t, "songs", cfg.PgDatabase)
+	// YAML file
+	require.Equal(t, "yaml.local", cfg.PgHost)
+	require.Equal(t, 7*time.Second, cfg.Timeout)
+	require.False(t, cfg.AuthPublicRead)
+	// Environment overrides YAML file
+	require.Equal(t, "7777", cfg.PgPort)
+	// Flag overrides environment
+	require.Equal(t, "flag", cfg.PgUser)
+	require.Equal(t, 2*time.Minute, cfg.IdleTimeout)
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	unknownKey := filepath.Join(dir, "unknown.yaml")
+	require.NoError(t, os.WriteFile(unknownKey, []byte("pg_hots: localhost\n"), 0o600))
+
+	cases := []struct {
+		name string
+		args []string
+	}{
+		{name: "Unknown flag", args: []string{"-pg-hots", "localhost"}},
+		{name: "Missing config file", args: []string{"-config", filepath.Join(dir, "missing.yaml")}},
+		{name: "Unknown key in config file", args: []string{"-config", unknownKey}},
+		{name: "Incorrect type", args: []string{"-timeout", "soon"}},
+		{name: "Invalid value", args: []string{"-environment", "staging"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := Load(tc.args)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg, _, err := Load(nil)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Environment = "staging"
+	cfg.PgPort = "postgres"
+	cfg.TLSCertFile = "tls.crt"
+	cfg.TracingSampleRatio = 2
+
+	err = cfg.Validate()
+	require.Error(t, err)
+
+	for _, variable := range []string{"ENVIRONMENT", "PG_PORT", "TLS_CERT_FILE", "TRACING_SAMPLE_RATIO"} {
+		require.Contains(t, err.Error(), variable)
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	cfg, _, err := Load([]string{"-pg-pass", "hunter2", "-jwt-hmac-secret", "s3cret"})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.Print(&buf))
+
+	out := buf.String()
+	require.Contains(t, out, "pg_host: localhost\n")
+	require.Contains(t, out, "pg_pass: "+redacted+"\n")
+	require.Contains(t, out, "jwt_hmac_secret: "+redacted+"\n")
+	require.NotContains(t, out, "hunter2")
+	require.NotContains(t, out, "s3cret")
+
+	// The printed config is a valid config file
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, buf.Bytes(), 0o600))
+
+	_, err = readYAML(configFile)
+	require.NoError(t, err)
+}
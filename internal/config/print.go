@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"reflect"
+	"strings"
+)
+
+const redacted = "<redacted>"
+
+// Print writes the effective config as a YAML config file, secret values are redacted.
+func (c *Config) Print(w io.Writer) error {
+	v := reflect.ValueOf(c).Elem()
+
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, f := range fields() {
+		value := fmt.Sprint(v.Field(f.index).Interface())
+		if f.secret && value != "" {
+			value = redacted
+		}
+
+		doc.Content = append(doc.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: strings.ToLower(f.envVar)},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value},
+		)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
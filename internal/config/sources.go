@@ -0,0 +1,124 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// field describes a config field set by the variable.
+type field struct {
+	name   string
+	envVar string
+	secret bool
+	index  int
+}
+
+// fields returns the config fields in the declaration order.
+func fields() []field {
+	t := reflect.TypeOf(Config{})
+
+	result := make([]field, 0, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		envVar, _, _ := strings.Cut(f.Tag.Get("env"), ",")
+		if envVar == "" {
+			continue
+		}
+
+		result = append(result, field{
+			name:   f.Name,
+			envVar: envVar,
+			secret: f.Tag.Get("secret") == "true",
+			index:  i,
+		})
+	}
+
+	return result
+}
+
+// flagName returns the command line flag of the variable, e.g. PG_HOST is set by -pg-host.
+func flagName(envVar string) string {
+	return strings.ToLower(strings.ReplaceAll(envVar, "_", "-"))
+}
+
+type flagValues struct {
+	configFile string
+	// Values of the flags set in the command line by variable name
+	values map[string]string
+	// Arguments after the flags
+	args []string
+}
+
+// parseFlags parses a flag for every config variable and the -config flag with the YAML file.
+func parseFlags(args []string) (flagValues, error) {
+	fs := flag.NewFlagSet("song-library", flag.ContinueOnError)
+
+	configFile := fs.String("config", "", "YAML config file, also set by CONFIG_FILE")
+
+	byFlag := make(map[string]string)
+	for _, f := range fields() {
+		name := flagName(f.envVar)
+		fs.String(name, "", "sets "+f.envVar)
+		byFlag[name] = f.envVar
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return flagValues{}, err
+	}
+
+	values := make(map[string]string)
+	fs.Visit(func(fl *flag.Flag) {
+		if envVar, ok := byFlag[fl.Name]; ok {
+			values[envVar] = fl.Value.String()
+		}
+	})
+
+	return flagValues{configFile: *configFile, values: values, args: fs.Args()}, nil
+}
+
+// readYAML reads the config file with the variables as keys, e.g.
+//
+//	pg_host: db.local
+//	timeout: 10s
+//
+// Keys are case-insensitive, unknown keys are errors.
+func readYAML(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file: %w", err)
+	}
+
+	var raw map[string]any
+	if err = yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unable to parse config file %s: %w", path, err)
+	}
+
+	known := make(map[string]struct{})
+	for _, f := range fields() {
+		known[f.envVar] = struct{}{}
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		envVar := strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		if _, ok := known[envVar]; !ok {
+			return nil, fmt.Errorf("config file %s: unknown key %q", path, key)
+		}
+
+		switch value.(type) {
+		case string, bool, int, float64:
+			values[envVar] = fmt.Sprint(value)
+		case nil:
+			values[envVar] = ""
+		default:
+			return nil, fmt.Errorf("config file %s: value of %q must be a scalar", path, key)
+		}
+	}
+
+	return values, nil
+}
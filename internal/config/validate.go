@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+var (
+	environments = []string{"local", "dev", "test", "prod"}
+	pgSSLModes   = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	outboxSinks  = []string{"", "stdout", "file", "http"}
+)
+
+// Validate checks the config values and returns all problems joined in one error.
+func (c *Config) Validate() error {
+	var errs []error
+
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains(environments, c.Environment),
+		"ENVIRONMENT: %q is not one of %v", c.Environment, environments)
+
+	_, _, err := net.SplitHostPort(c.Address)
+	check(err == nil, "ADDRESS: %q is not host:port", c.Address)
+
+	if c.GrpcAddress != "" {
+		_, _, err = net.SplitHostPort(c.GrpcAddress)
+		check(err == nil, "GRPC_ADDRESS: %q is not host:port", c.GrpcAddress)
+		check(c.GrpcAddress != c.Address, "GRPC_ADDRESS: must differ from ADDRESS")
+	}
+
+	check(c.Timeout > 0, "TIMEOUT: must be positive, got %s", c.Timeout)
+	check(c.IdleTimeout > 0, "IDLE_TIMEOUT: must be positive, got %s", c.IdleTimeout)
+	check(c.ShutdownDrainDelay >= 0, "SHUTDOWN_DRAIN_DELAY: must not be negative, got %s", c.ShutdownDrainDelay)
+
+	check(c.CompressMinSize >= 0, "COMPRESS_MIN_SIZE: must not be negative, got %d", c.CompressMinSize)
+	check(c.CacheMaxAge >= 0, "CACHE_MAX_AGE: must not be negative, got %s", c.CacheMaxAge)
+
+	check((c.TLSCertFile == "") == (c.TLSKeyFile == ""),
+		"TLS_CERT_FILE and TLS_KEY_FILE: both must be set to serve HTTPS")
+
+	if c.DatabaseURL == "" {
+		check(c.PgHost != "", "PG_HOST: must be set")
+
+		port, err := strconv.Atoi(c.PgPort)
+		check(err == nil && port > 0 && port < 65536, "PG_PORT: %q is not a port number", c.PgPort)
+
+		check(c.PgDatabase != "", "PG_DATABASE: must be set")
+		check(slices.Contains(pgSSLModes, c.PgSSLMode),
+			"PG_SSLMODE: %q is not one of %v", c.PgSSLMode, pgSSLModes)
+	}
+
+	check(c.PgMaxOpenConns >= 0, "PG_MAX_OPEN_CONNS: must not be negative, got %d", c.PgMaxOpenConns)
+	check(c.PgMaxIdleConns >= 0, "PG_MAX_IDLE_CONNS: must not be negative, got %d", c.PgMaxIdleConns)
+	check(c.PgConnMaxLifetime >= 0, "PG_CONN_MAX_LIFETIME: must not be negative, got %s", c.PgConnMaxLifetime)
+	check(c.PgConnectTimeout > 0, "PG_CONNECT_TIMEOUT: must be positive, got %s", c.PgConnectTimeout)
+	check(c.DbQueryTimeout >= 0, "DB_QUERY_TIMEOUT: must not be negative, got %s", c.DbQueryTimeout)
+
+	if c.MusicInfoURL != "" {
+		musicInfoURL, err := url.Parse(c.MusicInfoURL)
+		check(err == nil && musicInfoURL.Scheme != "" && musicInfoURL.Host != "",
+			"MUSICINFO_URL: %q is not an absolute URL", c.MusicInfoURL)
+	}
+
+	check(c.MusicInfoTimeout > 0, "MUSICINFO_TIMEOUT: must be positive, got %s", c.MusicInfoTimeout)
+	check(c.MusicInfoRetries >= 0, "MUSICINFO_RETRIES: must not be negative, got %d", c.MusicInfoRetries)
+	check(c.MusicInfoRetryBackoff >= 0, "MUSICINFO_RETRY_BACKOFF: must not be negative, got %s", c.MusicInfoRetryBackoff)
+	check(c.MusicInfoBreakerThreshold >= 0,
+		"MUSICINFO_BREAKER_THRESHOLD: must not be negative, got %d", c.MusicInfoBreakerThreshold)
+	check(c.MusicInfoBreakerCooldown >= 0,
+		"MUSICINFO_BREAKER_COOLDOWN: must not be negative, got %s", c.MusicInfoBreakerCooldown)
+
+	check(c.JobWorkers >= 0, "JOB_WORKERS: must not be negative, got %d", c.JobWorkers)
+	check(c.JobPollInterval > 0, "JOB_POLL_INTERVAL: must be positive, got %s", c.JobPollInterval)
+	check(c.JobMaxAttempts > 0, "JOB_MAX_ATTEMPTS: must be positive, got %d", c.JobMaxAttempts)
+	check(c.JobRetryBackoff >= 0, "JOB_RETRY_BACKOFF: must not be negative, got %s", c.JobRetryBackoff)
+	check(c.JobRetryMaxBackoff >= c.JobRetryBackoff,
+		"JOB_RETRY_MAX_BACKOFF: must not be less than JOB_RETRY_BACKOFF, got %s", c.JobRetryMaxBackoff)
+	check(c.JobTimeout > 0, "JOB_TIMEOUT: must be positive, got %s", c.JobTimeout)
+	check(c.JobShutdownTimeout >= 0, "JOB_SHUTDOWN_TIMEOUT: must not be negative, got %s", c.JobShutdownTimeout)
+
+	check(c.LinkCheckInterval >= 0, "LINK_CHECK_INTERVAL: must not be negative, got %s", c.LinkCheckInterval)
+	check(c.LinkCheckMaxAge >= 0, "LINK_CHECK_MAX_AGE: must not be negative, got %s", c.LinkCheckMaxAge)
+	check(c.LinkCheckConcurrency > 0, "LINK_CHECK_CONCURRENCY: must be positive, got %d", c.LinkCheckConcurrency)
+	check(c.LinkCheckTimeout > 0, "LINK_CHECK_TIMEOUT: must be positive, got %s", c.LinkCheckTimeout)
+	check(c.LinkCheckBatchSize > 0, "LINK_CHECK_BATCH_SIZE: must be positive, got %d", c.LinkCheckBatchSize)
+
+	check(c.WebhookTimeout > 0, "WEBHOOK_TIMEOUT: must be positive, got %s", c.WebhookTimeout)
+
+	check(c.EventsHeartbeat > 0, "EVENTS_HEARTBEAT: must be positive, got %s", c.EventsHeartbeat)
+	check(c.EventsRetention >= 0, "EVENTS_RETENTION: must not be negative, got %s", c.EventsRetention)
+
+	check(slices.Contains(outboxSinks, c.OutboxSink), "OUTBOX_SINK: %q is not one of %q", c.OutboxSink, outboxSinks)
+	check(c.OutboxSink != "file" || c.OutboxFile != "", "OUTBOX_FILE: must be set for the file sink")
+
+	if c.OutboxSink == "http" {
+		outboxURL, err := url.Parse(c.OutboxURL)
+		check(err == nil && outboxURL.Scheme != "" && outboxURL.Host != "",
+			"OUTBOX_URL: %q is not an absolute URL", c.OutboxURL)
+	}
+
+	check(c.OutboxPollInterval > 0, "OUTBOX_POLL_INTERVAL: must be positive, got %s", c.OutboxPollInterval)
+	check(c.OutboxBatchSize > 0, "OUTBOX_BATCH_SIZE: must be positive, got %d", c.OutboxBatchSize)
+	check(c.OutboxTimeout > 0, "OUTBOX_TIMEOUT: must be positive, got %s", c.OutboxTimeout)
+	check(c.OutboxRetryBackoff >= 0, "OUTBOX_RETRY_BACKOFF: must not be negative, got %s", c.OutboxRetryBackoff)
+	check(c.OutboxRetryMaxBackoff >= c.OutboxRetryBackoff,
+		"OUTBOX_RETRY_MAX_BACKOFF: must not be less than OUTBOX_RETRY_BACKOFF, got %s", c.OutboxRetryMaxBackoff)
+	check(c.OutboxRetention >= 0, "OUTBOX_RETENTION: must not be negative, got %s", c.OutboxRetention)
+
+	check(c.JwtRolesClaim != "", "JWT_ROLES_CLAIM: must be set")
+
+	check(c.RateLimitIPRPS >= 0, "RATE_LIMIT_IP_RPS: must not be negative, got %g", c.RateLimitIPRPS)
+	check(c.RateLimitIPRPS == 0 || c.RateLimitIPBurst > 0,
+		"RATE_LIMIT_IP_BURST: must be positive, got %d", c.RateLimitIPBurst)
+	check(c.RateLimitReadRPS >= 0, "RATE_LIMIT_READ_RPS: must not be negative, got %g", c.RateLimitReadRPS)
+	check(c.RateLimitReadRPS == 0 || c.RateLimitReadBurst > 0,
+		"RATE_LIMIT_READ_BURST: must be positive, got %d", c.RateLimitReadBurst)
+	check(c.RateLimitWriteRPS >= 0, "RATE_LIMIT_WRITE_RPS: must not be negative, got %g", c.RateLimitWriteRPS)
+	check(c.RateLimitWriteRPS == 0 || c.RateLimitWriteBurst > 0,
+		"RATE_LIMIT_WRITE_BURST: must be positive, got %d", c.RateLimitWriteBurst)
+
+	check(c.TracingSampleRatio >= 0 && c.TracingSampleRatio <= 1,
+		"TRACING_SAMPLE_RATIO: must be between 0 and 1, got %g", c.TracingSampleRatio)
+
+	return errors.Join(errs...)
+}
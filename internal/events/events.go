@@ -0,0 +1,256 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"song-library/internal/models"
+	"sync"
+	"time"
+)
+
+const (
+	// subscriberBuffer is the number of events a subscriber may lag behind, a slower one is dropped
+	subscriberBuffer = 64
+	// catchUpPageSize is the number of events read from the log at once when the broker catches up
+	catchUpPageSize = 500
+	// retryDelay is the delay before the broker listens again after a failure
+	retryDelay = 5 * time.Second
+	// pruneInterval is how often the events older than the retention are deleted
+	pruneInterval = time.Hour
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Storage
+type Storage interface {
+	SongEventsSince(ctx context.Context, afterID int64, limit int) ([]models.SongEvent, error)
+	SongEventsPrune(ctx context.Context, before time.Time) (deleted int64, err error)
+	LastSongEventID(ctx context.Context) (eventID int64, err error)
+	ListenSongEvents(ctx context.Context, log *slog.Logger, notify func(models.SongEvent), listening func()) error
+}
+
+// Broker broadcasts song events committed by any server replica to the subscribers
+// of this one. Events come from Postgres notifications, the ones missed while
+// the notifications were not received are read from the event log, so every
+// subscriber gets all events in the order of their ids.
+type Broker struct {
+	storage   Storage
+	log       *slog.Logger
+	retention time.Duration
+	now       func() time.Time
+
+	mu          sync.Mutex
+	subscribers map[chan models.SongEvent]struct{}
+	// lastID is the id of the latest broadcast event
+	lastID  int64
+	stopped bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New returns the broker of the events of the storage, the events older
+// than the retention are pruned from the event log, zero retention keeps them.
+func New(storage Storage, retention time.Duration, log *slog.Logger) *Broker {
+	return &Broker{
+		storage:     storage,
+		log:         log.With(slog.String("op", "events")),
+		retention:   retention,
+		now:         time.Now,
+		subscribers: make(map[chan models.SongEvent]struct{}),
+	}
+}
+
+// Subscribe returns a channel of the events broadcast from now on and the function
+// that unsubscribes it. The channel is closed when the subscriber is unsubscribed,
+// falls behind by more than the buffer or the broker is stopped.
+func (b *Broker) Subscribe() (<-chan models.SongEvent, func()) {
+	ch := make(chan models.SongEvent, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.stopped {
+		close(ch)
+	} else {
+		b.subscribers[ch] = struct{}{}
+	}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.drop(ch)
+	}
+}
+
+// Start listens for the events and prunes the event log until Stop.
+func (b *Broker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.done = make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		b.listen(ctx)
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		b.prune(ctx)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(b.done)
+	}()
+}
+
+// Stop stops the broker and closes the channels of all subscribers,
+// so the streams to the clients end and the server can shut down.
+func (b *Broker) Stop() {
+	if b.cancel == nil {
+		return
+	}
+
+	b.cancel()
+	<-b.done
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.stopped = true
+
+	for ch := range b.subscribers {
+		b.drop(ch)
+	}
+}
+
+func (b *Broker) listen(ctx context.Context) {
+	started := false
+
+	for {
+		var err error
+
+		if !started {
+			// Events before the start are not broadcast, they are replayed by the clients resuming from them
+			var lastID int64
+
+			lastID, err = b.storage.LastSongEventID(ctx)
+			if err == nil {
+				b.mu.Lock()
+				b.lastID = lastID
+				b.mu.Unlock()
+
+				started = true
+			}
+		}
+
+		if started {
+			b.log.Info("Listening for song events")
+
+			err = b.storage.ListenSongEvents(ctx, b.log, b.publish, func() { b.catchUp(ctx) })
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			b.log.Error("Failed to listen for song events", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// catchUp broadcasts the events logged after the latest broadcast one, it is called
+// when the listening starts or resumes as the notifications sent before are lost.
+func (b *Broker) catchUp(ctx context.Context) {
+	for {
+		b.mu.Lock()
+		afterID := b.lastID
+		b.mu.Unlock()
+
+		events, err := b.storage.SongEventsSince(ctx, afterID, catchUpPageSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				b.log.Error("Failed to catch up song events", slog.Any("error", err))
+			}
+
+			return
+		}
+
+		for _, event := range events {
+			b.publish(event)
+		}
+
+		if len(events) < catchUpPageSize {
+			return
+		}
+	}
+}
+
+// publish broadcasts the event unless it is already broadcast: an event may be
+// both notified and read from the log while the broker catches up.
+func (b *Broker) publish(event models.SongEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if event.ID <= b.lastID {
+		return
+	}
+
+	b.lastID = event.ID
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			b.log.Warn("Song events subscriber is too slow, dropping it")
+			b.drop(ch)
+		}
+	}
+}
+
+// drop closes the channel of the subscriber, b.mu must be held.
+func (b *Broker) drop(ch chan models.SongEvent) {
+	if _, ok := b.subscribers[ch]; !ok {
+		return
+	}
+
+	delete(b.subscribers, ch)
+	close(ch)
+}
+
+func (b *Broker) prune(ctx context.Context) {
+	if b.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := b.storage.SongEventsPrune(ctx, b.now().Add(-b.retention))
+		switch {
+		case err != nil && ctx.Err() == nil:
+			b.log.Error("Failed to prune song events", slog.Any("error", err))
+		case deleted > 0:
+			b.log.Info("Pruned song events", slog.Int64("deleted", deleted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
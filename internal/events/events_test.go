@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"song-library/internal/events/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"testing"
+	"time"
+)
+
+func songEvent(id int64) models.SongEvent {
+	return models.SongEvent{ID: id, Event: models.EventSongCreated, Song: models.Song{GroupName: "Muse", SongName: "Uprising"}}
+}
+
+func receive(t *testing.T, ch <-chan models.SongEvent) models.SongEvent {
+	t.Helper()
+
+	select {
+	case event, ok := <-ch:
+		require.True(t, ok, "channel is closed")
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+		return models.SongEvent{}
+	}
+}
+
+func TestBroker(t *testing.T) {
+	storageMock := mocks.NewStorage(t)
+	storageMock.On("LastSongEventID", mock.Anything).Return(int64(10), nil).Once()
+	// Events 11 and 12 are committed before the listening starts, 12 is also notified
+	storageMock.On("SongEventsSince", mock.Anything, int64(10), catchUpPageSize).
+		Return([]models.SongEvent{songEvent(11), songEvent(12)}, nil).Once()
+	storageMock.On("ListenSongEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			notify := args.Get(2).(func(models.SongEvent))
+			listening := args.Get(3).(func())
+
+			listening()
+			notify(songEvent(12))
+			notify(songEvent(13))
+
+			<-ctx.Done()
+		}).
+		Return(nil).Once()
+
+	broker := New(storageMock, 0, slogdiscard.NewDiscardLogger())
+
+	live, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	broker.Start()
+
+	require.Equal(t, int64(11), receive(t, live).ID)
+	require.Equal(t, int64(12), receive(t, live).ID)
+	require.Equal(t, int64(13), receive(t, live).ID)
+
+	broker.Stop()
+
+	_, ok := <-live
+	require.False(t, ok, "channel is not closed on stop")
+
+	stopped, _ := broker.Subscribe()
+	_, ok = <-stopped
+	require.False(t, ok, "channel is not closed after stop")
+}
+
+func TestBrokerDropsSlowSubscriber(t *testing.T) {
+	broker := New(mocks.NewStorage(t), 0, slogdiscard.NewDiscardLogger())
+
+	slow, _ := broker.Subscribe()
+	fast, unsubscribe := broker.Subscribe()
+	defer unsubscribe()
+
+	for id := range int64(subscriberBuffer + 1) {
+		broker.publish(songEvent(id + 1))
+		require.Equal(t, id+1, receive(t, fast).ID)
+	}
+
+	for range subscriberBuffer {
+		<-slow
+	}
+
+	_, ok := <-slow
+	require.False(t, ok, "slow subscriber is not dropped")
+}
+
+func TestBrokerPrune(t *testing.T) {
+	now := time.Date(2024, 10, 8, 12, 0, 0, 0, time.UTC)
+	pruned := make(chan struct{})
+
+	storageMock := mocks.NewStorage(t)
+	storageMock.On("LastSongEventID", mock.Anything).Return(int64(0), nil).Maybe()
+	storageMock.On("SongEventsSince", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	storageMock.On("ListenSongEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(nil).Maybe()
+	storageMock.On("SongEventsPrune", mock.Anything, now.Add(-7*24*time.Hour)).
+		Run(func(mock.Arguments) { close(pruned) }).
+		Return(int64(3), nil).Once()
+
+	broker := New(storageMock, 7*24*time.Hour, slogdiscard.NewDiscardLogger())
+	broker.now = func() time.Time { return now }
+
+	broker.Start()
+	defer broker.Stop()
+
+	select {
+	case <-pruned:
+	case <-time.After(time.Second):
+		require.FailNow(t, "events are not pruned")
+	}
+}
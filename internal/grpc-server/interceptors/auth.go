@@ -0,0 +1,160 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"song-library/internal/apikey"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/mwauth"
+	"song-library/internal/storage"
+	"strings"
+)
+
+// MetadataAPIKey is the metadata key with the API key, gRPC metadata keys are lowercase.
+const MetadataAPIKey = "x-api-key"
+
+type principalKey struct{}
+
+// Auth authenticates the calls like the REST API: by API key in the x-api-key metadata
+// or by JWT in the "authorization: Bearer" metadata, API keys have the admin role.
+// roles maps full method names to the required roles, methods not in roles are public.
+type Auth struct {
+	log           *slog.Logger
+	apiKeyFinder  mwauth.APIKeyFinder
+	tokenVerifier mwauth.TokenVerifier
+	roles         map[string]auth.Role
+}
+
+func NewAuth(log *slog.Logger, apiKeyFinder mwauth.APIKeyFinder, tokenVerifier mwauth.TokenVerifier,
+	roles map[string]auth.Role) *Auth {
+	return &Auth{
+		log:           log.With(slog.String("op", "grpc.interceptors.auth")),
+		apiKeyFinder:  apiKeyFinder,
+		tokenVerifier: tokenVerifier,
+		roles:         roles,
+	}
+}
+
+func (a *Auth) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (a *Auth) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// PrincipalFrom returns the client the call was authenticated as.
+func PrincipalFrom(ctx context.Context) (auth.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(auth.Principal)
+	return principal, ok
+}
+
+// authorize returns the context with the principal if the call has credentials.
+// Invalid credentials are Unauthenticated, as well as missing ones if the method
+// requires a role, and the principal without the role is PermissionDenied.
+func (a *Auth) authorize(ctx context.Context, method string) (context.Context, error) {
+	principal, ok, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		setSubject(ctx, principal.Subject)
+
+		ctx = context.WithValue(ctx, principalKey{}, principal)
+	}
+
+	role, required := a.roles[method]
+	if !required {
+		return ctx, nil
+	}
+
+	if !ok {
+		a.log.InfoContext(ctx, "Unauthenticated: credentials are missing", slog.String("method", method))
+
+		return nil, status.Error(codes.Unauthenticated, "credentials are missing")
+	}
+
+	if !principal.Has(role) {
+		a.log.InfoContext(ctx, "Permission denied: role is not allowed",
+			slog.String("method", method),
+			slog.String("subject", principal.Subject),
+			slog.String("subject_role", string(principal.Role)))
+
+		return nil, status.Errorf(codes.PermissionDenied, "role %s is required", role)
+	}
+
+	return ctx, nil
+}
+
+func (a *Auth) authenticate(ctx context.Context) (auth.Principal, bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if key := first(md, MetadataAPIKey); key != "" {
+		apiKey, err := a.apiKeyFinder.APIKeyFind(ctx, apikey.Hash(key))
+		if err != nil {
+			if errors.Is(err, storage.ErrAPIKeyNotFound) {
+				a.log.InfoContext(ctx, "Unauthenticated: API key is invalid or revoked")
+
+				return auth.Principal{}, false, status.Error(codes.Unauthenticated, "API key is invalid or revoked")
+			}
+
+			a.log.ErrorContext(ctx, "Failed to find API key", slog.Any("error", err))
+
+			return auth.Principal{}, false, status.Error(codes.Internal, "internal error")
+		}
+
+		return auth.APIKeyPrincipal(apiKey.ID, apiKey.Name), true, nil
+	}
+
+	scheme, token, ok := strings.Cut(first(md, "authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return auth.Principal{}, false, nil
+	}
+
+	principal, err := a.tokenVerifier.Verify(strings.TrimSpace(token))
+	if err != nil {
+		a.log.InfoContext(ctx, "Unauthenticated: bearer token is invalid", slog.Any("error", err))
+
+		return auth.Principal{}, false, status.Error(codes.Unauthenticated, "bearer token is invalid")
+	}
+
+	return principal, true, nil
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// serverStream replaces the context of the stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
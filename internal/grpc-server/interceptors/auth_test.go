@@ -0,0 +1,115 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"song-library/internal/apikey"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/mwauth/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"testing"
+)
+
+func TestAuthUnary(t *testing.T) {
+	const (
+		publicMethod = "/songlibrary.v1.SongLibrary/Info"
+		editorMethod = "/songlibrary.v1.SongLibrary/Update"
+	)
+
+	cases := []struct {
+		name      string
+		method    string
+		md        metadata.MD
+		principal auth.Principal
+		code      codes.Code
+	}{
+		{
+			name:   "Anonymous public method",
+			method: publicMethod,
+			code:   codes.OK,
+		},
+		{
+			name:   "Anonymous protected method",
+			method: editorMethod,
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:      "Valid API key",
+			method:    editorMethod,
+			md:        metadata.Pairs(MetadataAPIKey, "valid"),
+			principal: auth.Principal{Subject: "apikey:1:importer", APIKeyID: 1, Role: auth.RoleAdmin},
+			code:      codes.OK,
+		},
+		{
+			name:   "Revoked API key",
+			method: publicMethod,
+			md:     metadata.Pairs(MetadataAPIKey, "revoked"),
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:      "Editor token",
+			method:    editorMethod,
+			md:        metadata.Pairs("authorization", "Bearer editor"),
+			principal: auth.Principal{Subject: "alice", Role: auth.RoleEditor},
+			code:      codes.OK,
+		},
+		{
+			name:   "Reader token",
+			method: editorMethod,
+			md:     metadata.Pairs("authorization", "Bearer reader"),
+			code:   codes.PermissionDenied,
+		},
+		{
+			name:   "Invalid token",
+			method: publicMethod,
+			md:     metadata.Pairs("authorization", "Bearer invalid"),
+			code:   codes.Unauthenticated,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiKeyFinderMock := mocks.NewAPIKeyFinder(t)
+			apiKeyFinderMock.On("APIKeyFind", mock.Anything, apikey.Hash("valid")).
+				Return(models.APIKey{ID: 1, Name: "importer"}, nil).Maybe()
+			apiKeyFinderMock.On("APIKeyFind", mock.Anything, apikey.Hash("revoked")).
+				Return(models.APIKey{}, storage.ErrAPIKeyNotFound).Maybe()
+
+			tokenVerifierMock := mocks.NewTokenVerifier(t)
+			tokenVerifierMock.On("Verify", "editor").
+				Return(auth.Principal{Subject: "alice", Role: auth.RoleEditor}, nil).Maybe()
+			tokenVerifierMock.On("Verify", "reader").
+				Return(auth.Principal{Subject: "bob", Role: auth.RoleReader}, nil).Maybe()
+			tokenVerifierMock.On("Verify", "invalid").
+				Return(auth.Principal{}, errors.New("token is expired")).Maybe()
+
+			authInterceptor := NewAuth(slogdiscard.NewDiscardLogger(), apiKeyFinderMock, tokenVerifierMock,
+				map[string]auth.Role{editorMethod: auth.RoleEditor})
+
+			ctx := metadata.NewIncomingContext(context.Background(), tc.md)
+
+			var principal auth.Principal
+
+			_, err := authInterceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method},
+				func(ctx context.Context, _ any) (any, error) {
+					principal, _ = PrincipalFrom(ctx)
+					return nil, nil
+				})
+
+			require.Equal(t, tc.code, status.Code(err))
+			require.Equal(t, tc.principal, principal)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package interceptors
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"time"
+)
+
+type callFieldsKey struct{}
+
+// callFields are filled by the next interceptors and logged when the call is completed.
+type callFields struct {
+	subject string
+}
+
+// Logger logs every completed call with its status code, like the request logger of the REST API.
+func Logger(log *slog.Logger) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	log.Info("gRPC logger interceptor enabled")
+
+	logCall := func(ctx context.Context, method string, start time.Time, fields *callFields, err error) {
+		attrs := []any{
+			slog.String("method", method),
+			slog.String("code", status.Code(err).String()),
+			slog.String("duration", time.Since(start).String()),
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			attrs = append(attrs, slog.String("remote_addr", p.Addr.String()))
+		}
+		if fields.subject != "" {
+			attrs = append(attrs, slog.String("subject", fields.subject))
+		}
+
+		log.InfoContext(ctx, "Call completed", attrs...)
+	}
+
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		fields := &callFields{}
+
+		resp, err := handler(context.WithValue(ctx, callFieldsKey{}, fields), req)
+
+		logCall(ctx, info.FullMethod, start, fields, err)
+
+		return resp, err
+	}
+
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		fields := &callFields{}
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: context.WithValue(ss.Context(), callFieldsKey{}, fields)})
+
+		logCall(ss.Context(), info.FullMethod, start, fields, err)
+
+		return err
+	}
+
+	return unary, stream
+}
+
+// setSubject records the authenticated subject of the call in the "Call completed" log record.
+func setSubject(ctx context.Context, subject string) {
+	if fields, ok := ctx.Value(callFieldsKey{}).(*callFields); ok {
+		fields.subject = subject
+	}
+}
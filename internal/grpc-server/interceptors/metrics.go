@@ -0,0 +1,57 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// Metrics counts the calls and observes their latency by full method name and status code,
+// like the metrics middleware of the REST API.
+func Metrics(registerer prometheus.Registerer) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "song_library",
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Number of gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "song_library",
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of gRPC calls by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	registerer.MustRegister(requests, duration)
+
+	observe := func(method string, start time.Time, err error) {
+		requests.WithLabelValues(method, status.Code(err).String()).Inc()
+		duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}
+
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		observe(info.FullMethod, start, err)
+
+		return err
+	}
+
+	return unary, stream
+}
@@ -0,0 +1,34 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+	"testing"
+)
+
+func TestMetricsUnary(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	unary, _ := Metrics(registry)
+
+	for _, code := range []codes.Code{codes.OK, codes.NotFound, codes.NotFound} {
+		_, _ = unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/songlibrary.v1.SongLibrary/Info"},
+			func(ctx context.Context, req any) (any, error) {
+				return nil, status.Error(code, code.String())
+			})
+	}
+
+	expected := `
+# HELP song_library_grpc_requests_total Number of gRPC calls by method and status code.
+# TYPE song_library_grpc_requests_total counter
+song_library_grpc_requests_total{code="NotFound",method="/songlibrary.v1.SongLibrary/Info"} 2
+song_library_grpc_requests_total{code="OK",method="/songlibrary.v1.SongLibrary/Info"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected), "song_library_grpc_requests_total"))
+}
@@ -0,0 +1,117 @@
+package interceptors
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"math"
+	"net"
+	"song-library/internal/http-server/mwratelimit"
+	"strconv"
+	"time"
+)
+
+// MetadataRetryAfter is the header metadata with the seconds until the limited call may be retried.
+const MetadataRetryAfter = "retry-after"
+
+// KeyFunc returns the key of the bucket the call takes a token from.
+type KeyFunc func(ctx context.Context) string
+
+// RateLimit limits the calls like the rate limiter middleware of the REST API, so a shared store
+// limits the client on both APIs. Limited calls get ResourceExhausted with the retry-after header.
+// Only the methods for which limited returns true are limited, all of them if limited is nil.
+// If the limit rate is zero, the interceptors are disabled.
+func RateLimit(log *slog.Logger, store mwratelimit.Store, limit mwratelimit.Limit, key KeyFunc,
+	limited func(method string) bool) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	log = log.With(slog.String("op", "grpc.interceptors.ratelimit"))
+
+	if limit.Rate <= 0 {
+		log.Info("gRPC rate limiter interceptor disabled")
+
+		limited = func(string) bool { return false }
+	} else {
+		log.Info("gRPC rate limiter interceptor enabled",
+			slog.Float64("rate", limit.Rate),
+			slog.Int("burst", limit.Burst))
+	}
+
+	// take returns the retry-after header of the limited call, nil if the call is allowed
+	take := func(ctx context.Context, method string) metadata.MD {
+		if limited != nil && !limited(method) {
+			return nil
+		}
+
+		key := key(ctx)
+
+		result, err := store.Take(key, limit, time.Now())
+		if err != nil {
+			// Fail open: an unavailable store must not stop the service
+			log.ErrorContext(ctx, "Failed to take rate limit token", slog.Any("error", err))
+
+			return nil
+		}
+
+		if result.Allowed {
+			return nil
+		}
+
+		log.InfoContext(ctx, "Too many requests",
+			slog.String("method", method),
+			slog.String("client", key))
+
+		return metadata.Pairs(MetadataRetryAfter, strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+	}
+
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if header := take(ctx, info.FullMethod); header != nil {
+			_ = grpc.SetHeader(ctx, header)
+
+			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+		}
+
+		return handler(ctx, req)
+	}
+
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if header := take(ss.Context(), info.FullMethod); header != nil {
+			_ = ss.SetHeader(header)
+
+			return status.Error(codes.ResourceExhausted, "too many requests")
+		}
+
+		return handler(srv, ss)
+	}
+
+	return unary, stream
+}
+
+// ByPeerIP limits the calls by IP address of the peer, with the same keys as mwratelimit.ByIP.
+// It limits the calls with invalid credentials too if it is used before Auth.
+func ByPeerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "ip:"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	return "ip:" + host
+}
+
+// ByClient limits authenticated clients by API key or JWT subject and anonymous clients
+// by IP address, with the same keys as mwratelimit.ByClient, so it must be used after Auth.
+func ByClient(ctx context.Context) string {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return ByPeerIP(ctx)
+	}
+
+	return mwratelimit.ClientKey(principal)
+}
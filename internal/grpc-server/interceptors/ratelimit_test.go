@@ -0,0 +1,55 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/mwratelimit"
+	"song-library/internal/logger/slogdiscard"
+	"testing"
+)
+
+func TestRateLimitUnary(t *testing.T) {
+	const (
+		readMethod  = "/songlibrary.v1.SongLibrary/Info"
+		writeMethod = "/songlibrary.v1.SongLibrary/Update"
+	)
+
+	unary, _ := RateLimit(slogdiscard.NewDiscardLogger(), mwratelimit.NewMemoryStore(),
+		mwratelimit.Limit{Rate: 1, Burst: 1}, ByClient,
+		func(method string) bool { return method == writeMethod })
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 50000}})
+	editorCtx := context.WithValue(ctx, principalKey{}, auth.Principal{Subject: "editor", Role: auth.RoleEditor})
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+
+	call := func(ctx context.Context, method string) codes.Code {
+		_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return status.Code(err)
+	}
+
+	require.Equal(t, codes.OK, call(ctx, writeMethod))
+	require.Equal(t, codes.ResourceExhausted, call(ctx, writeMethod))
+	// The authenticated client has its own bucket
+	require.Equal(t, codes.OK, call(editorCtx, writeMethod))
+	// Methods that are not selected are not limited
+	require.Equal(t, codes.OK, call(ctx, readMethod))
+	require.Equal(t, codes.OK, call(ctx, readMethod))
+}
+
+func TestClientKeys(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 50000}})
+
+	require.Equal(t, "ip:10.0.0.1", ByPeerIP(ctx))
+	require.Equal(t, "ip:10.0.0.1", ByClient(ctx))
+	require.Equal(t, "apikey:3", ByClient(context.WithValue(ctx, principalKey{}, auth.Principal{APIKeyID: 3})))
+	require.Equal(t, "sub:editor", ByClient(context.WithValue(ctx, principalKey{}, auth.Principal{Subject: "editor"})))
+}
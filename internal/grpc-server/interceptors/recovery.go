@@ -0,0 +1,46 @@
+package interceptors
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"runtime/debug"
+)
+
+// Recovery turns a panic in the handler into the Internal status, so the server keeps running.
+func Recovery(log *slog.Logger) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	recovered := func(ctx context.Context, method string, p any) error {
+		log.ErrorContext(ctx, "Panic in gRPC handler",
+			slog.String("method", method),
+			slog.Any("panic", p),
+			slog.String("stack", string(debug.Stack())))
+
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ctx, info.FullMethod, p)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), info.FullMethod, p)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+
+	return unary, stream
+}
@@ -0,0 +1,92 @@
+package interceptors
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"song-library/internal/tracing"
+	"strings"
+)
+
+// Tracing starts a server span named after the full method for every call.
+// The parent span is taken from the W3C traceparent metadata.
+func Tracing(provider trace.TracerProvider,
+	propagator propagation.TextMapPropagator) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	tracer := provider.Tracer(tracing.InstrumentationName)
+
+	start := func(ctx context.Context, method string) (context.Context, trace.Span) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = propagator.Extract(ctx, metadataCarrier(md))
+
+		service, rpcMethod, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+
+		return tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.RPCSystemGRPC,
+				semconv.RPCService(service),
+				semconv.RPCMethod(rpcMethod),
+			),
+		)
+	}
+
+	end := func(span trace.Span, err error) {
+		code := status.Code(err)
+
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+		if code != codes.OK {
+			span.SetStatus(otelcodes.Error, code.String())
+		}
+
+		span.End()
+	}
+
+	unary := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := start(ctx, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+
+		end(span, err)
+
+		return resp, err
+	}
+
+	stream := func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := start(ss.Context(), info.FullMethod)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+		end(span, err)
+
+		return err
+	}
+
+	return unary, stream
+}
+
+// metadataCarrier adapts the incoming metadata to the propagator.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	return first(metadata.MD(c), key)
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
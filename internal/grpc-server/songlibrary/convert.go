@@ -0,0 +1,90 @@
+package songlibrary
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	songlibraryv1 "song-library/api/songlibrary/v1"
+	"song-library/internal/models"
+	"song-library/internal/songtext"
+)
+
+var linkStatuses = map[songlibraryv1.LinkStatus]models.LinkStatus{
+	songlibraryv1.LinkStatus_LINK_STATUS_OK:          models.LinkOK,
+	songlibraryv1.LinkStatus_LINK_STATUS_BROKEN:      models.LinkBroken,
+	songlibraryv1.LinkStatus_LINK_STATUS_UNREACHABLE: models.LinkUnreachable,
+	songlibraryv1.LinkStatus_LINK_STATUS_UNCHECKED:   models.LinkUnchecked,
+}
+
+var textPaginations = map[songlibraryv1.TextPagination]string{
+	songlibraryv1.TextPagination_TEXT_PAGINATION_UNSPECIFIED: songtext.ByVerse,
+	songlibraryv1.TextPagination_TEXT_PAGINATION_VERSE:       songtext.ByVerse,
+	songlibraryv1.TextPagination_TEXT_PAGINATION_LINE:        songtext.ByLine,
+	songlibraryv1.TextPagination_TEXT_PAGINATION_CHARS:       songtext.ByChars,
+}
+
+// songFromProto returns the song, both names are required.
+func songFromProto(song *songlibraryv1.Song) (models.Song, error) {
+	if song.GetGroup() == "" || song.GetSong() == "" {
+		return models.Song{}, status.Error(codes.InvalidArgument, "group or song name is missing")
+	}
+
+	return models.Song{GroupName: song.GetGroup(), SongName: song.GetSong()}, nil
+}
+
+func filterFromProto(filter *songlibraryv1.SongFilter) (models.SongWithDetail, error) {
+	var songFilter models.SongWithDetail
+
+	songFilter.GroupName = filter.GetGroup()
+	songFilter.SongName = filter.GetSong()
+	songFilter.SongDetail.ReleaseDate = filter.GetReleaseDate()
+	songFilter.SongDetail.Link = filter.GetLink()
+
+	if filter.GetLinkStatus() != songlibraryv1.LinkStatus_LINK_STATUS_UNSPECIFIED {
+		linkStatus, ok := linkStatuses[filter.GetLinkStatus()]
+		if !ok {
+			return models.SongWithDetail{}, status.Error(codes.InvalidArgument, "unknown link status")
+		}
+
+		songFilter.LinkCheck = &models.LinkCheck{Status: linkStatus}
+	}
+
+	return songFilter, nil
+}
+
+func songDetailToProto(songDetail models.SongDetail) *songlibraryv1.SongDetail {
+	detail := &songlibraryv1.SongDetail{
+		ReleaseDate: songDetail.ReleaseDate,
+		Text:        songDetail.Text,
+		Link:        songDetail.Link,
+	}
+
+	if !songDetail.UpdatedAt.IsZero() {
+		detail.UpdatedAt = timestamppb.New(songDetail.UpdatedAt)
+	}
+
+	return detail
+}
+
+func songWithDetailToProto(song models.SongWithDetail) *songlibraryv1.SongWithDetail {
+	result := &songlibraryv1.SongWithDetail{
+		Song:   &songlibraryv1.Song{Group: song.GroupName, Song: song.SongName},
+		Detail: songDetailToProto(song.SongDetail),
+	}
+
+	if song.LinkCheck != nil {
+		result.LinkCheck = &songlibraryv1.LinkCheck{
+			StatusCode: int32(song.LinkCheck.StatusCode),
+			RedirectTo: song.LinkCheck.RedirectTo,
+			CheckedAt:  timestamppb.New(song.LinkCheck.CheckedAt),
+		}
+
+		for protoStatus, linkStatus := range linkStatuses {
+			if linkStatus == song.LinkCheck.Status {
+				result.LinkCheck.Status = protoStatus
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,191 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "song-library/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// SaveSong provides a mock function with given fields: ctx, groupName, songName
+func (_m *Storage) SaveSong(ctx context.Context, groupName string, songName string) (int, error) {
+	ret := _m.Called(ctx, groupName, songName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSong")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return rf(ctx, groupName, songName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = rf(ctx, groupName, songName)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupName, songName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SongDelete provides a mock function with given fields: ctx, groupName, songName
+func (_m *Storage) SongDelete(ctx context.Context, groupName string, songName string) (int, error) {
+	ret := _m.Called(ctx, groupName, songName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SongDelete")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return rf(ctx, groupName, songName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = rf(ctx, groupName, songName)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupName, songName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SongInfo provides a mock function with given fields: ctx, groupName, songName
+func (_m *Storage) SongInfo(ctx context.Context, groupName string, songName string) (models.SongDetail, error) {
+	ret := _m.Called(ctx, groupName, songName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SongInfo")
+	}
+
+	var r0 models.SongDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (models.SongDetail, error)); ok {
+		return rf(ctx, groupName, songName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) models.SongDetail); ok {
+		r0 = rf(ctx, groupName, songName)
+	} else {
+		r0 = ret.Get(0).(models.SongDetail)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupName, songName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SongUpdate provides a mock function with given fields: ctx, groupName, songName, songDetail
+func (_m *Storage) SongUpdate(ctx context.Context, groupName string, songName string, songDetail models.SongDetail) error {
+	ret := _m.Called(ctx, groupName, songName, songDetail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SongUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.SongDetail) error); ok {
+		r0 = rf(ctx, groupName, songName, songDetail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SongsGet provides a mock function with given fields: ctx, filter, page, limit
+func (_m *Storage) SongsGet(ctx context.Context, filter models.SongWithDetail, page int, limit int) ([]models.SongWithDetail, error) {
+	ret := _m.Called(ctx, filter, page, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SongsGet")
+	}
+
+	var r0 []models.SongWithDetail
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SongWithDetail, int, int) ([]models.SongWithDetail, error)); ok {
+		return rf(ctx, filter, page, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SongWithDetail, int, int) []models.SongWithDetail); ok {
+		r0 = rf(ctx, filter, page, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SongWithDetail)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SongWithDetail, int, int) error); ok {
+		r1 = rf(ctx, filter, page, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Translations provides a mock function with given fields: ctx, groupName, songName
+func (_m *Storage) Translations(ctx context.Context, groupName string, songName string) ([]models.SongTranslation, error) {
+	ret := _m.Called(ctx, groupName, songName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Translations")
+	}
+
+	var r0 []models.SongTranslation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.SongTranslation, error)); ok {
+		return rf(ctx, groupName, songName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.SongTranslation); ok {
+		r0 = rf(ctx, groupName, songName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SongTranslation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupName, songName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Storage {
+	mock := &Storage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,308 @@
+package songlibrary
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	songlibraryv1 "song-library/api/songlibrary/v1"
+	"song-library/internal/auth"
+	"song-library/internal/jobs"
+	"song-library/internal/library"
+	"song-library/internal/models"
+	"song-library/internal/songtext"
+	"song-library/internal/storage"
+	"song-library/internal/translation"
+)
+
+const (
+	// defaultStreamPageSize is the number of songs read at once by StreamList if the page size is not set
+	defaultStreamPageSize = 100
+	maxStreamPageSize     = 1000
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Storage
+type Storage interface {
+	SaveSong(ctx context.Context, groupName string, songName string) (songID int, err error)
+	SongInfo(ctx context.Context, groupName string, songName string) (models.SongDetail, error)
+	Translations(ctx context.Context, groupName string, songName string) ([]models.SongTranslation, error)
+	SongUpdate(ctx context.Context, groupName string, songName string, songDetail models.SongDetail) error
+	SongDelete(ctx context.Context, groupName string, songName string) (songID int, err error)
+	SongsGet(ctx context.Context, filter models.SongWithDetail, page int, limit int) ([]models.SongWithDetail, error)
+}
+
+// Server implements the SongLibrary gRPC service on the storage of the REST API.
+type Server struct {
+	songlibraryv1.UnimplementedSongLibraryServer
+
+	log     *slog.Logger
+	storage Storage
+	jobs    jobs.Enqueuer
+}
+
+// New returns the SongLibrary service. Like POST /songs, Save enqueues the enrichment
+// of the new song if jobEnqueuer is not nil.
+func New(log *slog.Logger, storage Storage, jobEnqueuer jobs.Enqueuer) *Server {
+	return &Server{
+		log:     log.With(slog.String("op", "grpc.songlibrary")),
+		storage: storage,
+		jobs:    jobEnqueuer,
+	}
+}
+
+// Roles returns the role required by every method. The methods for readers
+// are public if publicRead is set, like GET endpoints of the REST API.
+func Roles(publicRead bool) map[string]auth.Role {
+	roles := map[string]auth.Role{
+		songlibraryv1.SongLibrary_Save_FullMethodName:   auth.RoleEditor,
+		songlibraryv1.SongLibrary_Update_FullMethodName: auth.RoleEditor,
+		songlibraryv1.SongLibrary_Delete_FullMethodName: auth.RoleAdmin,
+	}
+
+	if !publicRead {
+		for _, method := range []string{
+			songlibraryv1.SongLibrary_Info_FullMethodName,
+			songlibraryv1.SongLibrary_List_FullMethodName,
+			songlibraryv1.SongLibrary_StreamList_FullMethodName,
+			songlibraryv1.SongLibrary_Text_FullMethodName,
+		} {
+			roles[method] = auth.RoleReader
+		}
+	}
+
+	return roles
+}
+
+// Writes returns the methods that change songs, they share the rate limit of the REST writes.
+func Writes() map[string]bool {
+	return map[string]bool{
+		songlibraryv1.SongLibrary_Save_FullMethodName:   true,
+		songlibraryv1.SongLibrary_Update_FullMethodName: true,
+		songlibraryv1.SongLibrary_Delete_FullMethodName: true,
+	}
+}
+
+func (s *Server) Save(ctx context.Context, req *songlibraryv1.SaveRequest) (*songlibraryv1.SaveResponse, error) {
+	song, err := songFromProto(req.GetSong())
+	if err != nil {
+		return nil, err
+	}
+
+	jobID, err := library.SaveSong(ctx, s.log, s.storage, s.jobs, song)
+	if err != nil {
+		if errors.Is(err, storage.ErrSongExists) {
+			return nil, status.Error(codes.AlreadyExists, "song already exists")
+		}
+
+		return nil, s.internal(ctx, "Failed to save song", err)
+	}
+
+	return &songlibraryv1.SaveResponse{EnrichJobId: jobID}, nil
+}
+
+func (s *Server) Info(ctx context.Context, req *songlibraryv1.InfoRequest) (*songlibraryv1.InfoResponse, error) {
+	song, err := songFromProto(req.GetSong())
+	if err != nil {
+		return nil, err
+	}
+
+	songDetail, lang, err := s.songDetail(ctx, song, req.GetLang())
+	if err != nil {
+		return nil, err
+	}
+
+	return &songlibraryv1.InfoResponse{Detail: songDetailToProto(songDetail), Lang: lang}, nil
+}
+
+func (s *Server) Update(ctx context.Context, req *songlibraryv1.UpdateRequest) (*songlibraryv1.UpdateResponse, error) {
+	song, err := songFromProto(req.GetSong())
+	if err != nil {
+		return nil, err
+	}
+
+	songDetail := models.SongDetail{
+		ReleaseDate: req.GetDetail().GetReleaseDate(),
+		Text:        req.GetDetail().GetText(),
+		Link:        req.GetDetail().GetLink(),
+	}
+
+	err = s.storage.SongUpdate(ctx, song.GroupName, song.SongName, songDetail)
+	if err != nil {
+		if errors.Is(err, storage.ErrSongNotFound) {
+			return nil, status.Error(codes.NotFound, "song not found")
+		}
+
+		return nil, s.internal(ctx, "Failed to update song", err)
+	}
+
+	return &songlibraryv1.UpdateResponse{}, nil
+}
+
+func (s *Server) Delete(ctx context.Context, req *songlibraryv1.DeleteRequest) (*songlibraryv1.DeleteResponse, error) {
+	song, err := songFromProto(req.GetSong())
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.storage.SongDelete(ctx, song.GroupName, song.SongName)
+	if err != nil {
+		if errors.Is(err, storage.ErrSongNotFound) {
+			return nil, status.Error(codes.NotFound, "song not found")
+		}
+
+		return nil, s.internal(ctx, "Failed to delete song", err)
+	}
+
+	return &songlibraryv1.DeleteResponse{}, nil
+}
+
+func (s *Server) List(ctx context.Context, req *songlibraryv1.ListRequest) (*songlibraryv1.ListResponse, error) {
+	if req.GetPage() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "page must be positive")
+	}
+
+	if req.GetLimit() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be positive")
+	}
+
+	filter, err := filterFromProto(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	songs, err := s.songs(ctx, filter, int(req.GetPage()), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &songlibraryv1.ListResponse{
+		Songs: make([]*songlibraryv1.SongWithDetail, 0, len(songs)),
+		Page:  req.GetPage(),
+		Limit: req.GetLimit(),
+		Items: int32(len(songs)),
+	}
+
+	for _, song := range songs {
+		resp.Songs = append(resp.Songs, songWithDetailToProto(song))
+	}
+
+	return resp, nil
+}
+
+// StreamList sends the songs page by page, so large listings are not held in memory.
+// Songs changed during the stream may be missed or sent twice.
+func (s *Server) StreamList(req *songlibraryv1.StreamListRequest, stream songlibraryv1.SongLibrary_StreamListServer) error {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0 || pageSize > maxStreamPageSize:
+		return status.Errorf(codes.InvalidArgument, "page size must be from 1 to %d", maxStreamPageSize)
+	case pageSize == 0:
+		pageSize = defaultStreamPageSize
+	}
+
+	filter, err := filterFromProto(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	for page := 1; ; page++ {
+		songs, err := s.songs(stream.Context(), filter, page, pageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, song := range songs {
+			if err = stream.Send(songWithDetailToProto(song)); err != nil {
+				return err
+			}
+		}
+
+		if len(songs) < pageSize {
+			return nil
+		}
+	}
+}
+
+func (s *Server) Text(ctx context.Context, req *songlibraryv1.TextRequest) (*songlibraryv1.TextResponse, error) {
+	song, err := songFromProto(req.GetSong())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetPage() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "page must be positive")
+	}
+
+	by, ok := textPaginations[req.GetBy()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "unknown text pagination")
+	}
+
+	pageSize := int(req.GetSize())
+	switch {
+	case pageSize < 0:
+		return nil, status.Error(codes.InvalidArgument, "size must not be negative")
+	case pageSize == 0:
+		pageSize = songtext.DefaultPageSize(by)
+	}
+
+	songDetail, lang, err := s.songDetail(ctx, song, req.GetLang())
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := songtext.Paginate(songDetail.Text, by, pageSize, req.GetUnique())
+	if err != nil {
+		return nil, s.internal(ctx, "Failed to paginate song text", err)
+	}
+
+	if int(req.GetPage()) > len(pages) {
+		return nil, status.Errorf(codes.OutOfRange, "the song text has %d pages", len(pages))
+	}
+
+	return &songlibraryv1.TextResponse{
+		Song:       req.GetSong(),
+		Text:       pages[req.GetPage()-1],
+		Page:       req.GetPage(),
+		TotalPages: int32(len(pages)),
+		Lang:       lang,
+	}, nil
+}
+
+// songDetail returns the song detail with the text translated to the language if the translation exists,
+// and the language of the translation, empty for the original text.
+func (s *Server) songDetail(ctx context.Context, song models.Song, lang string) (models.SongDetail, string, error) {
+	songDetail, err := s.storage.SongInfo(ctx, song.GroupName, song.SongName)
+	if err != nil {
+		if errors.Is(err, storage.ErrSongNotFound) {
+			return models.SongDetail{}, "", status.Error(codes.NotFound, "song not found")
+		}
+
+		return models.SongDetail{}, "", s.internal(ctx, "Failed to find song", err)
+	}
+
+	textLang, err := translation.Translate(ctx, s.storage, song, &songDetail, translation.Requested(lang, ""))
+	if err != nil {
+		return models.SongDetail{}, "", s.internal(ctx, "Failed to find song translations", err)
+	}
+
+	return songDetail, textLang, nil
+}
+
+// songs returns a page of the songs, no songs are not an error.
+func (s *Server) songs(ctx context.Context, filter models.SongWithDetail, page int, limit int) ([]models.SongWithDetail, error) {
+	songs, err := s.storage.SongsGet(ctx, filter, page, limit)
+	if err != nil && !errors.Is(err, storage.ErrSongNotFound) {
+		return nil, s.internal(ctx, "Failed to get songs", err)
+	}
+
+	return songs, nil
+}
+
+// internal logs the error and returns the Internal status, the error is not sent to the client.
+func (s *Server) internal(ctx context.Context, msg string, err error) error {
+	s.log.ErrorContext(ctx, msg, slog.Any("error", err))
+
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,234 @@
+package songlibrary
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	songlibraryv1 "song-library/api/songlibrary/v1"
+	"song-library/internal/grpc-server/songlibrary/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"testing"
+)
+
+// newClient serves the service over an in-memory connection.
+func newClient(t *testing.T, storageMock Storage) songlibraryv1.SongLibraryClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer()
+	songlibraryv1.RegisterSongLibraryServer(server, New(slogdiscard.NewDiscardLogger(), storageMock, nil))
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return songlibraryv1.NewSongLibraryClient(conn)
+}
+
+func TestSave(t *testing.T) {
+	cases := []struct {
+		name      string
+		song      *songlibraryv1.Song
+		infoError error
+		saveError error
+		code      codes.Code
+	}{
+		{
+			name:      "Success",
+			song:      &songlibraryv1.Song{Group: "test_group", Song: "test_song"},
+			infoError: storage.ErrSongNotFound,
+			code:      codes.OK,
+		},
+		{
+			name: "Empty song",
+			song: &songlibraryv1.Song{Group: "test_group"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "Song exists",
+			song: &songlibraryv1.Song{Group: "test_group", Song: "test_song"},
+			code: codes.AlreadyExists,
+		},
+		{
+			name:      "Storage error",
+			song:      &songlibraryv1.Song{Group: "test_group", Song: "test_song"},
+			infoError: storage.ErrSongNotFound,
+			saveError: errors.New("internal error"),
+			code:      codes.Internal,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			storageMock := mocks.NewStorage(t)
+
+			storageMock.On("SongInfo", mock.Anything, tc.song.GetGroup(), tc.song.GetSong()).
+				Return(models.SongDetail{}, tc.infoError).Maybe()
+			storageMock.On("SaveSong", mock.Anything, tc.song.GetGroup(), tc.song.GetSong()).
+				Return(1, tc.saveError).Maybe()
+
+			client := newClient(t, storageMock)
+
+			_, err := client.Save(context.Background(), &songlibraryv1.SaveRequest{Song: tc.song})
+
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []struct {
+		name      string
+		mockError error
+		code      codes.Code
+	}{
+		{
+			name: "Success",
+			code: codes.OK,
+		},
+		{
+			name:      "Song not found",
+			mockError: storage.ErrSongNotFound,
+			code:      codes.NotFound,
+		},
+		{
+			name:      "Storage error",
+			mockError: errors.New("internal error"),
+			code:      codes.Internal,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			storageMock := mocks.NewStorage(t)
+
+			storageMock.On("SongDelete", mock.Anything, "test_group", "test_song").Return(1, tc.mockError)
+
+			client := newClient(t, storageMock)
+
+			_, err := client.Delete(context.Background(), &songlibraryv1.DeleteRequest{
+				Song: &songlibraryv1.Song{Group: "test_group", Song: "test_song"},
+			})
+
+			require.Equal(t, tc.code, status.Code(err))
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	storageMock := mocks.NewStorage(t)
+
+	filter := models.SongWithDetail{
+		Song:      models.Song{GroupName: "test_group"},
+		LinkCheck: &models.LinkCheck{Status: models.LinkBroken},
+	}
+
+	storageMock.On("SongsGet", mock.Anything, filter, 2, 10).Return([]models.SongWithDetail{
+		{Song: models.Song{GroupName: "test_group", SongName: "test_song"}},
+	}, nil)
+
+	client := newClient(t, storageMock)
+
+	resp, err := client.List(context.Background(), &songlibraryv1.ListRequest{
+		Filter: &songlibraryv1.SongFilter{Group: "test_group", LinkStatus: songlibraryv1.LinkStatus_LINK_STATUS_BROKEN},
+		Page:   2,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, resp.GetSongs(), 1)
+	require.Equal(t, "test_song", resp.GetSongs()[0].GetSong().GetSong())
+	require.EqualValues(t, 1, resp.GetItems())
+
+	_, err = client.List(context.Background(), &songlibraryv1.ListRequest{Page: 0, Limit: 10})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStreamList(t *testing.T) {
+	storageMock := mocks.NewStorage(t)
+
+	page := func(names ...string) []models.SongWithDetail {
+		songs := make([]models.SongWithDetail, 0, len(names))
+		for _, name := range names {
+			songs = append(songs, models.SongWithDetail{Song: models.Song{GroupName: "test_group", SongName: name}})
+		}
+
+		return songs
+	}
+
+	// The stream ends after the first page shorter than the page size
+	storageMock.On("SongsGet", mock.Anything, models.SongWithDetail{}, 1, 2).Return(page("a", "b"), nil).Once()
+	storageMock.On("SongsGet", mock.Anything, models.SongWithDetail{}, 2, 2).Return(page("c", "d"), nil).Once()
+	storageMock.On("SongsGet", mock.Anything, models.SongWithDetail{}, 3, 2).Return(page("e"), nil).Once()
+
+	client := newClient(t, storageMock)
+
+	stream, err := client.StreamList(context.Background(), &songlibraryv1.StreamListRequest{PageSize: 2})
+	require.NoError(t, err)
+
+	var names []string
+
+	for {
+		song, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		names = append(names, song.GetSong().GetSong())
+	}
+
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, names)
+}
+
+func TestText(t *testing.T) {
+	storageMock := mocks.NewStorage(t)
+
+	storageMock.On("SongInfo", mock.Anything, "test_group", "test_song").
+		Return(models.SongDetail{Text: "first verse\n\nsecond verse"}, nil)
+
+	client := newClient(t, storageMock)
+
+	song := &songlibraryv1.Song{Group: "test_group", Song: "test_song"}
+
+	resp, err := client.Text(context.Background(), &songlibraryv1.TextRequest{Song: song, Page: 2, Size: 1})
+	require.NoError(t, err)
+
+	require.Equal(t, "second verse", resp.GetText())
+	require.EqualValues(t, 2, resp.GetTotalPages())
+
+	_, err = client.Text(context.Background(), &songlibraryv1.TextRequest{Song: song, Page: 3, Size: 1})
+	require.Equal(t, codes.OutOfRange, status.Code(err))
+}
@@ -0,0 +1,121 @@
+package certreload
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// checkInterval limits how often the certificate files are checked for changes,
+// so a burst of TLS handshakes does not stat the files every time.
+const checkInterval = time.Second
+
+// Reloader serves the TLS certificate from the certificate and key files
+// and reloads it when the files change, so a renewed certificate is used without restart.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	log      *slog.Logger
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	certMod   time.Time
+	keyMod    time.Time
+	lastCheck time.Time
+	now       func() time.Time
+}
+
+// New loads the certificate. An error is returned if the files can not be loaded,
+// later reload errors are logged and the previous certificate is kept.
+func New(certFile string, keyFile string, log *slog.Logger) (*Reloader, error) {
+	const op = "certreload.New"
+
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		log:      log.With(slog.String("op", "certreload"), slog.String("cert_file", certFile)),
+		now:      time.Now,
+	}
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = r.load(certMod, keyMod); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return r, nil
+}
+
+// GetCertificate returns the current certificate, it is used as tls.Config.GetCertificate.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.lastCheck) < checkInterval {
+		return r.cert, nil
+	}
+	r.lastCheck = now
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		r.log.Error("Unable to check certificate files", slog.Any("error", err))
+		return r.cert, nil
+	}
+
+	if certMod.Equal(r.certMod) && keyMod.Equal(r.keyMod) {
+		return r.cert, nil
+	}
+
+	// The certificate and the key may be replaced one by one,
+	// a mismatched pair fails to load and is retried on the next check
+	if err = r.load(certMod, keyMod); err != nil {
+		r.log.Error("Unable to reload certificate, the previous one is used", slog.Any("error", err))
+		return r.cert, nil
+	}
+
+	r.log.Info("Certificate reloaded")
+
+	return r.cert, nil
+}
+
+// TLSConfig returns the server TLS config with the reloaded certificate.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+	}
+}
+
+func (r *Reloader) load(certMod time.Time, keyMod time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load certificate: %w", err)
+	}
+
+	r.cert = &cert
+	r.certMod = certMod
+	r.keyMod = keyMod
+
+	return nil
+}
+
+func (r *Reloader) modTimes() (certMod time.Time, keyMod time.Time, err error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
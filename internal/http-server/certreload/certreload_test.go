@@ -0,0 +1,100 @@
+package certreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"os"
+	"path/filepath"
+	"song-library/internal/logger/slogdiscard"
+	"testing"
+	"time"
+)
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	writeCert(t, certFile, keyFile, "first")
+
+	reloader, err := New(certFile, keyFile, slogdiscard.NewDiscardLogger())
+	require.NoError(t, err)
+
+	now := time.Now()
+	reloader.now = func() time.Time { return now }
+
+	require.Equal(t, "first", commonName(t, reloader))
+
+	// Replace the certificate, the change is noticed after the check interval
+	writeCert(t, certFile, keyFile, "second")
+	touch(t, now.Add(time.Minute), certFile, keyFile)
+
+	require.Equal(t, "first", commonName(t, reloader))
+
+	now = now.Add(checkInterval)
+	require.Equal(t, "second", commonName(t, reloader))
+
+	// A broken certificate is not used
+	require.NoError(t, os.WriteFile(certFile, []byte("broken"), 0o600))
+	touch(t, now.Add(2*time.Minute), certFile)
+
+	now = now.Add(checkInterval)
+	require.Equal(t, "second", commonName(t, reloader))
+}
+
+func TestNewMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := New(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), slogdiscard.NewDiscardLogger())
+	require.Error(t, err)
+}
+
+func commonName(t *testing.T, reloader *Reloader) string {
+	t.Helper()
+
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+
+	return leaf.Subject.CommonName
+}
+
+func touch(t *testing.T, modTime time.Time, files ...string) {
+	t.Helper()
+
+	for _, file := range files {
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+}
+
+// writeCert writes a self-signed certificate with the common name and its key.
+func writeCert(t *testing.T, certFile string, keyFile string, commonName string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+}
@@ -0,0 +1,182 @@
+package eventsget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"io"
+	"log/slog"
+	"net/http"
+	"song-library/internal/models"
+	"strconv"
+	"time"
+)
+
+// replayPageSize is the number of events read from the log at once when a client resumes
+const replayPageSize = 500
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=EventsGetter
+type EventsGetter interface {
+	SongEventsSince(ctx context.Context, afterID int64, limit int) ([]models.SongEvent, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Subscriber
+type Subscriber interface {
+	Subscribe() (<-chan models.SongEvent, func())
+}
+
+// New returns the handler of GET /events, the stream of song events as Server-Sent Events.
+// A client resuming from the Last-Event-ID header (or lastEventId parameter) first gets
+// the events logged after it, then the live ones. A comment is sent every heartbeat
+// to keep idle connections open.
+func New(log *slog.Logger, eventsGetter EventsGetter, subscriber Subscriber, heartbeat time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.events.get"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get("lastEventId")
+		}
+
+		var sentID int64
+		resume := lastEventID != ""
+
+		if resume {
+			var err error
+
+			sentID, err = strconv.ParseInt(lastEventID, 10, 64)
+			if err != nil || sentID < 0 {
+				log.InfoContext(r.Context(), "Bad request: last event id is incorrect",
+					slog.String("last_event_id", lastEventID))
+
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		// Subscribed before the replay, so no event is missed between them
+		live, unsubscribe := subscriber.Subscribe()
+		defer unsubscribe()
+
+		var replay []models.SongEvent
+
+		if resume {
+			var err error
+
+			replay, err = eventsGetter.SongEventsSince(r.Context(), sentID, replayPageSize)
+			if err != nil {
+				log.ErrorContext(r.Context(), "Failed to get song events", slog.Any("error", err))
+
+				w.WriteHeader(http.StatusInternalServerError)
+
+				return
+			}
+		}
+
+		rc := http.NewResponseController(w)
+
+		// The stream outlives the write timeout of the server
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			log.DebugContext(r.Context(), "Failed to clear write deadline", slog.Any("error", err))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		// Disables response buffering of nginx
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		send := func(event models.SongEvent) error {
+			if err := writeEvent(w, event); err != nil {
+				return err
+			}
+
+			sentID = event.ID
+
+			return nil
+		}
+
+		for resume {
+			for _, event := range replay {
+				if err := send(event); err != nil {
+					log.DebugContext(r.Context(), "Client is gone", slog.Any("error", err))
+					return
+				}
+			}
+
+			if len(replay) < replayPageSize {
+				break
+			}
+
+			var err error
+
+			replay, err = eventsGetter.SongEventsSince(r.Context(), sentID, replayPageSize)
+			if err != nil {
+				// The client reconnects and resumes from the last sent event
+				log.ErrorContext(r.Context(), "Failed to get song events", slog.Any("error", err))
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			log.ErrorContext(r.Context(), "Failed to flush events", slog.Any("error", err))
+			return
+		}
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			var err error
+
+			select {
+			case <-r.Context().Done():
+				return
+			case event, ok := <-live:
+				if !ok {
+					// The subscriber fell behind or the server is shutting down,
+					// the client reconnects and resumes from the last sent event
+					return
+				}
+
+				// Events already replayed
+				if event.ID <= sentID {
+					continue
+				}
+
+				err = send(event)
+			case <-ticker.C:
+				_, err = io.WriteString(w, ": ping\n\n")
+			}
+
+			if err == nil {
+				err = rc.Flush()
+			}
+
+			if err != nil {
+				log.DebugContext(r.Context(), "Client is gone", slog.Any("error", err))
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes the event in the text/event-stream format.
+func writeEvent(w io.Writer, event models.SongEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, data)
+
+	return err
+}
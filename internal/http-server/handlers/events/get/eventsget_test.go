@@ -0,0 +1,133 @@
+package eventsget
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/http-server/handlers/events/get/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"testing"
+	"time"
+)
+
+func songEvent(id int64, event string) models.SongEvent {
+	return models.SongEvent{
+		ID:         id,
+		Event:      event,
+		Song:       models.Song{GroupName: "Muse", SongName: "Uprising"},
+		OccurredAt: time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func frame(id int64, event string) string {
+	return fmt.Sprintf("id: %d\nevent: %s\ndata: "+
+		`{"id":%d,"event":%q,"song":{"group":"Muse","song":"Uprising"},"occurredAt":"2024-10-01T12:00:00Z"}`+
+		"\n\n", id, event, id, event)
+}
+
+func TestEventsGetHandler(t *testing.T) {
+	cases := []struct {
+		name        string
+		lastEventID string
+		url         string
+		resume      bool
+		replay      []models.SongEvent
+		mockError   error
+		live        []models.SongEvent
+		httpStatus  int
+		body        string
+	}{
+		{
+			name:       "Live events",
+			url:        "/events",
+			live:       []models.SongEvent{songEvent(1, models.EventSongCreated), songEvent(2, models.EventSongDeleted)},
+			httpStatus: http.StatusOK,
+			body:       frame(1, models.EventSongCreated) + frame(2, models.EventSongDeleted),
+		},
+		{
+			name:        "Resume from Last-Event-ID",
+			url:         "/events",
+			lastEventID: "1",
+			resume:      true,
+			replay:      []models.SongEvent{songEvent(2, models.EventSongUpdated), songEvent(3, models.EventSongDeleted)},
+			live:        []models.SongEvent{songEvent(3, models.EventSongDeleted), songEvent(4, models.EventSongCreated)},
+			httpStatus:  http.StatusOK,
+			body: frame(2, models.EventSongUpdated) + frame(3, models.EventSongDeleted) +
+				frame(4, models.EventSongCreated),
+		},
+		{
+			name:       "Resume from parameter",
+			url:        "/events?lastEventId=1",
+			resume:     true,
+			replay:     []models.SongEvent{songEvent(2, models.EventSongUpdated)},
+			httpStatus: http.StatusOK,
+			body:       frame(2, models.EventSongUpdated),
+		},
+		{
+			name:        "Incorrect Last-Event-ID",
+			url:         "/events",
+			lastEventID: "first",
+			httpStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "Storage error",
+			url:         "/events",
+			lastEventID: "1",
+			resume:      true,
+			mockError:   errors.New("unexpected error"),
+			httpStatus:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			eventsGetterMock := mocks.NewEventsGetter(t)
+			subscriberMock := mocks.NewSubscriber(t)
+
+			if tc.httpStatus != http.StatusBadRequest {
+				live := make(chan models.SongEvent, len(tc.live))
+				for _, event := range tc.live {
+					live <- event
+				}
+				close(live)
+
+				unsubscribed := false
+				subscriberMock.On("Subscribe").
+					Return((<-chan models.SongEvent)(live), func() { unsubscribed = true }).Once()
+				t.Cleanup(func() { require.True(t, unsubscribed) })
+			}
+
+			if tc.resume {
+				eventsGetterMock.On("SongEventsSince", mock.Anything, int64(1), replayPageSize).
+					Return(tc.replay, tc.mockError).Once()
+			}
+
+			handler := New(slogdiscard.NewDiscardLogger(), eventsGetterMock, subscriberMock, time.Minute)
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			if tc.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tc.lastEventID)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.httpStatus, rr.Code)
+
+			if tc.httpStatus != http.StatusOK {
+				return
+			}
+
+			require.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+			require.Equal(t, "no-cache", rr.Header().Get("Cache-Control"))
+			require.True(t, rr.Flushed)
+			require.Equal(t, tc.body, rr.Body.String())
+		})
+	}
+}
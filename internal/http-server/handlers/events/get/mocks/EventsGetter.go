@@ -0,0 +1,60 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "song-library/internal/models"
+)
+
+// EventsGetter is an autogenerated mock type for the EventsGetter type
+type EventsGetter struct {
+	mock.Mock
+}
+
+// SongEventsSince provides a mock function with given fields: ctx, afterID, limit
+func (_m *EventsGetter) SongEventsSince(ctx context.Context, afterID int64, limit int) ([]models.SongEvent, error) {
+	ret := _m.Called(ctx, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SongEventsSince")
+	}
+
+	var r0 []models.SongEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]models.SongEvent, error)); ok {
+		return rf(ctx, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []models.SongEvent); ok {
+		r0 = rf(ctx, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SongEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventsGetter creates a new instance of EventsGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventsGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventsGetter {
+	mock := &EventsGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
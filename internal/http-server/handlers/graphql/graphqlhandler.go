@@ -0,0 +1,112 @@
+package graphqlhandler
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/graph-gophers/graphql-go"
+	graphqlotel "github.com/graph-gophers/graphql-go/trace/otel"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"song-library/internal/jobs"
+	"song-library/internal/models"
+)
+
+const (
+	// maxDepth limits the nesting of queries, e.g. groups { songs { group { songs ... } } }
+	maxDepth = 8
+	// maxParallelism is the number of fields resolved at once, it is also
+	// the largest batch of groups whose songs are loaded in one query
+	maxParallelism = 100
+	// maxGroupSongs limits the songs of a group, so a batch loads up to maxParallelism * maxGroupSongs songs
+	maxGroupSongs = 100
+	// maxBodySize limits the request body, it is read before the rate limiters
+	maxBodySize = 1 << 20
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Storage
+type Storage interface {
+	SaveSong(ctx context.Context, groupName string, songName string) (songID int, err error)
+	SongInfo(ctx context.Context, groupName string, songName string) (models.SongDetail, error)
+	SongUpdate(ctx context.Context, groupName string, songName string, songDetail models.SongDetail) error
+	SongDelete(ctx context.Context, groupName string, songName string) (songID int, err error)
+	SongsGet(ctx context.Context, filter models.SongWithDetail, page int, limit int) ([]models.SongWithDetail, error)
+	Groups(ctx context.Context, name string, page int, limit int) ([]models.Group, error)
+	SongsByGroups(ctx context.Context, groupNames []string, limit int) (map[string][]models.SongWithDetail, error)
+}
+
+// Request is the body of POST /graphql.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// New returns the handler of POST /graphql. Queries read songs and groups like GET /songs,
+// the songs of all groups in the response are loaded by one query. Mutations check the role
+// of the client like the REST routes and enqueue the enrichment of saved songs if jobEnqueuer
+// is not nil.
+func New(log *slog.Logger, storage Storage, jobEnqueuer jobs.Enqueuer) http.HandlerFunc {
+	const op = "handlers.graphql"
+
+	log = log.With(slog.String("op", op))
+
+	schema := graphql.MustParseSchema(schemaString,
+		&resolver{log: log, storage: storage, jobs: jobEnqueuer},
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism),
+		graphql.Tracer(graphqlotel.DefaultTracer()),
+		graphql.Logger(panicLogger{log: log}),
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		log := log.With(slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req Request
+
+		err := render.DecodeJSON(http.MaxBytesReader(w, r.Body, maxBodySize), &req)
+		if err != nil {
+			if errors.As(err, new(*http.MaxBytesError)) {
+				log.InfoContext(r.Context(), "Request body is too large")
+
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			log.InfoContext(r.Context(), "Bad request: failed to decode request body", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		log.InfoContext(r.Context(), "Start request POST /graphql", slog.String("operation", req.OperationName))
+
+		// Loaders batch and cache the storage reads of one request only
+		ctx := withLoaders(r.Context(), log, storage)
+
+		resp := schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+		if len(resp.Errors) > 0 {
+			log.InfoContext(r.Context(), "Request completed with errors", slog.Any("errors", resp.Errors))
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
+
+// panicLogger logs panics of the resolvers, graphql-go returns them to the client as errors.
+type panicLogger struct {
+	log *slog.Logger
+}
+
+func (l panicLogger) LogPanic(ctx context.Context, value any) {
+	l.log.ErrorContext(ctx, "Panic in GraphQL resolver",
+		slog.Any("panic", value),
+		slog.String("stack", string(debug.Stack())))
+}
@@ -0,0 +1,176 @@
+package graphqlhandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"song-library/internal/apikey"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/handlers/graphql/mocks"
+	"song-library/internal/http-server/mwauth"
+	authmocks "song-library/internal/http-server/mwauth/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"testing"
+)
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code string `json:"code"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// do sends the query to the handler behind the auth middleware, the "admin" API key
+// authenticates an admin and the "reader" bearer token a reader.
+func do(t *testing.T, storageMock Storage, query string, header http.Header) response {
+	t.Helper()
+
+	apiKeyFinderMock := authmocks.NewAPIKeyFinder(t)
+	apiKeyFinderMock.On("APIKeyFind", mock.Anything, apikey.Hash("admin")).
+		Return(models.APIKey{Name: "admin"}, nil).Maybe()
+
+	tokenVerifierMock := authmocks.NewTokenVerifier(t)
+	tokenVerifierMock.On("Verify", "reader").
+		Return(auth.Principal{Subject: "reader", Role: auth.RoleReader}, nil).Maybe()
+
+	log := slogdiscard.NewDiscardLogger()
+	handler := mwauth.New(log, apiKeyFinderMock, tokenVerifierMock)(New(log, storageMock, nil))
+
+	body, err := json.Marshal(Request{Query: query})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	require.NoError(t, err)
+
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	return resp
+}
+
+func TestGroupSongsAreBatched(t *testing.T) {
+	storageMock := mocks.NewStorage(t)
+
+	storageMock.On("Groups", mock.Anything, "", 1, 10).
+		Return([]models.Group{{Name: "a"}, {Name: "b"}, {Name: "c"}}, nil)
+
+	// One query loads the songs of all groups
+	storageMock.On("SongsByGroups", mock.Anything, mock.MatchedBy(func(groupNames []string) bool {
+		slices.Sort(groupNames)
+		return slices.Equal(groupNames, []string{"a", "b", "c"})
+	}), 2).Return(map[string][]models.SongWithDetail{
+		"a": {{Song: models.Song{GroupName: "a", SongName: "a1"}, SongDetail: models.SongDetail{Text: "text"}}},
+		"c": {{Song: models.Song{GroupName: "c", SongName: "c1"}}, {Song: models.Song{GroupName: "c", SongName: "c2"}}},
+	}, nil).Once()
+
+	resp := do(t, storageMock, `{ groups { name songs(limit: 2) { name detail { text } } } }`, nil)
+
+	require.Empty(t, resp.Errors)
+	require.JSONEq(t, `{"groups": [
+		{"name": "a", "songs": [{"name": "a1", "detail": {"text": "text"}}]},
+		{"name": "b", "songs": []},
+		{"name": "c", "songs": [{"name": "c1", "detail": {"text": ""}}, {"name": "c2", "detail": {"text": ""}}]}
+	]}`, string(resp.Data))
+}
+
+func TestGroupSongsLimit(t *testing.T) {
+	storageMock := mocks.NewStorage(t)
+
+	storageMock.On("Groups", mock.Anything, "", 1, 10).Return([]models.Group{{Name: "a"}}, nil)
+
+	resp := do(t, storageMock, `{ groups { name songs(limit: 1000) { name } } }`, nil)
+
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, CodeBadUserInput, resp.Errors[0].Extensions.Code)
+}
+
+func TestSongsFilter(t *testing.T) {
+	storageMock := mocks.NewStorage(t)
+
+	filter := models.SongWithDetail{
+		Song:      models.Song{GroupName: "test_group"},
+		LinkCheck: &models.LinkCheck{Status: models.LinkBroken},
+	}
+
+	storageMock.On("SongsGet", mock.Anything, filter, 2, 5).Return([]models.SongWithDetail{{
+		Song:      models.Song{GroupName: "test_group", SongName: "test_song"},
+		LinkCheck: &models.LinkCheck{Status: models.LinkBroken, StatusCode: http.StatusNotFound},
+	}}, nil)
+
+	resp := do(t, storageMock,
+		`{ songs(filter: {group: "test_group", linkStatus: BROKEN}, page: 2, limit: 5) {
+			name group { name } linkCheck { status statusCode } } }`, nil)
+
+	require.Empty(t, resp.Errors)
+	require.JSONEq(t, `{"songs": [{"name": "test_song", "group": {"name": "test_group"},
+		"linkCheck": {"status": "BROKEN", "statusCode": 404}}]}`, string(resp.Data))
+}
+
+func TestDeleteSongRoles(t *testing.T) {
+	cases := []struct {
+		name    string
+		header  http.Header
+		deleted bool
+		code    string
+	}{
+		{
+			name: "Anonymous",
+			code: CodeUnauthenticated,
+		},
+		{
+			name:   "Reader",
+			header: http.Header{"Authorization": {"Bearer reader"}},
+			code:   CodeForbidden,
+		},
+		{
+			name:    "Admin",
+			header:  http.Header{mwauth.HeaderAPIKey: {"admin"}},
+			deleted: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			storageMock := mocks.NewStorage(t)
+
+			if tc.deleted {
+				storageMock.On("SongDelete", mock.Anything, "test_group", "test_song").Return(1, nil)
+			}
+
+			resp := do(t, storageMock,
+				`mutation { deleteSong(song: {group: "test_group", song: "test_song"}) }`, tc.header)
+
+			if tc.code == "" {
+				require.Empty(t, resp.Errors)
+				require.JSONEq(t, `{"deleteSong": true}`, string(resp.Data))
+				return
+			}
+
+			require.Len(t, resp.Errors, 1)
+			require.Equal(t, tc.code, resp.Errors[0].Extensions.Code)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package graphqlhandler
+
+import (
+	"context"
+	"github.com/graph-gophers/dataloader"
+	"log/slog"
+	"song-library/internal/models"
+	"sync"
+)
+
+type loadersKey struct{}
+
+// loaders hold the dataloaders of one request. The songs of groups are loaded by one
+// query per limit, so groups { songs } costs two queries instead of one per group.
+type loaders struct {
+	log     *slog.Logger
+	storage Storage
+
+	mu sync.Mutex
+	// groupSongs are keyed by the limit of songs per group
+	groupSongs map[int]*dataloader.Loader
+}
+
+func withLoaders(ctx context.Context, log *slog.Logger, storage Storage) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		log:        log,
+		storage:    storage,
+		groupSongs: make(map[int]*dataloader.Loader),
+	})
+}
+
+// loadGroupSongs returns up to limit songs of the group. Calls made while
+// the fields are resolved in parallel are batched into one storage query.
+func loadGroupSongs(ctx context.Context, groupName string, limit int) ([]models.SongWithDetail, error) {
+	l := ctx.Value(loadersKey{}).(*loaders)
+
+	l.mu.Lock()
+	loader, ok := l.groupSongs[limit]
+	if !ok {
+		loader = dataloader.NewBatchedLoader(l.groupSongsBatch(limit), dataloader.WithBatchCapacity(maxParallelism))
+		l.groupSongs[limit] = loader
+	}
+	l.mu.Unlock()
+
+	value, err := loader.Load(ctx, dataloader.StringKey(groupName))()
+	if err != nil {
+		return nil, err
+	}
+
+	// Groups without songs have no data
+	songs, _ := value.([]models.SongWithDetail)
+
+	return songs, nil
+}
+
+func (l *loaders) groupSongsBatch(limit int) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		results := make([]*dataloader.Result, len(keys))
+
+		songs, err := l.storage.SongsByGroups(ctx, keys.Keys(), limit)
+		if err != nil {
+			l.log.ErrorContext(ctx, "Failed to get songs of groups", slog.Any("error", err))
+		}
+
+		for i, key := range keys {
+			results[i] = &dataloader.Result{Data: songs[key.String()], Error: err}
+		}
+
+		return results
+	}
+}
@@ -0,0 +1,105 @@
+package graphqlhandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Limit returns middleware that passes mutations to the write limiter and other operations
+// to the read limiter, so GraphQL takes the same tokens as the REST writes and reads.
+// Requests that can not be decoded take the read token, the handler rejects them.
+// Bodies larger than maxBodySize get 413 Request Entity Too Large.
+func Limit(readLimiter, writeLimiter func(next http.Handler) http.Handler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		reads := readLimiter(next)
+		writes := writeLimiter(next)
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+			if errors.As(err, new(*http.MaxBytesError)) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			// The handler decodes the body again
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			var req Request
+			if err == nil && json.Unmarshal(body, &req) == nil && isMutation(req.Query) {
+				writes.ServeHTTP(w, r)
+				return
+			}
+
+			reads.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// isMutation reports whether the document has a mutation. It does not select the operation
+// by its name, so a document with both queries and mutations is limited as a write.
+// Names are only checked outside of selection sets, arguments and lists, and strings and
+// comments are skipped, so a field or a value named "mutation" is not taken for one.
+func isMutation(query string) bool {
+	depth := 0
+
+	for i := 0; i < len(query); {
+		c := query[i]
+
+		switch {
+		case c == '#':
+			end := strings.IndexAny(query[i:], "\n\r")
+			if end < 0 {
+				return false
+			}
+			i += end
+		case strings.HasPrefix(query[i:], `"""`):
+			i += 3
+			for i < len(query) && !strings.HasPrefix(query[i:], `"""`) {
+				if strings.HasPrefix(query[i:], `\"""`) {
+					i += 4
+					continue
+				}
+				i++
+			}
+			i += 3
+		case c == '"':
+			i++
+			for i < len(query) && query[i] != '"' && query[i] != '\n' {
+				if query[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			i++
+		case c == '{' || c == '(' || c == '[':
+			depth++
+			i++
+		case c == '}' || c == ')' || c == ']':
+			depth--
+			i++
+		case isNameStart(c):
+			start := i
+			for i < len(query) && (isNameStart(query[i]) || query[i] >= '0' && query[i] <= '9') {
+				i++
+			}
+
+			if depth == 0 && query[start:i] == "mutation" {
+				return true
+			}
+		default:
+			i++
+		}
+	}
+
+	return false
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,101 @@
+package graphqlhandler
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsMutation(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		mutation bool
+	}{
+		{
+			name:  "Query shorthand",
+			query: `{ songs(page: 1, limit: 10) { name } }`,
+		},
+		{
+			name:  "Named query",
+			query: `query Songs($page: Int!) { songs(page: $page, limit: 10) { name } }`,
+		},
+		{
+			name:     "Mutation",
+			query:    `mutation { deleteSong(song: {group: "Muse", song: "Uprising"}) }`,
+			mutation: true,
+		},
+		{
+			name:     "Mutation after comment and query",
+			query:    "# songs\nquery A { songs(page: 1, limit: 1) { name } }\nmutation B { deleteSong(song: {group: \"Muse\", song: \"Uprising\"}) }",
+			mutation: true,
+		},
+		{
+			name:  "Mutation in strings and comments",
+			query: "# mutation\n{ songs(filter: {song: \"mutation\", group: \"\\\" mutation\"}, page: 1, limit: 1) { name } }",
+		},
+		{
+			name:  "Mutation in block string",
+			query: `{ songs(filter: {song: """ } mutation \""" """}, page: 1, limit: 1) { name } }`,
+		},
+		{
+			name:  "Field named mutation",
+			query: `{ mutation: songs(page: 1, limit: 1) { name } }`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.mutation, isMutation(tc.query))
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	var limiter string
+
+	named := func(name string) func(next http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				limiter = name
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	const body = `{"query":"mutation { deleteSong(song: {group: \"Muse\", song: \"Uprising\"}) }"}`
+
+	handler := Limit(named("read"), named("write"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		// The body is passed to the handler
+		require.Equal(t, body, string(received))
+	}))
+
+	req, err := http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "write", limiter)
+
+	req, err = http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader([]byte(`{"query":"{ groups(page: 1, limit: 1) { name } }"}`)))
+	require.NoError(t, err)
+
+	handler = Limit(named("read"), named("write"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "read", limiter)
+
+	// A large body is rejected before the limiters
+	limiter = ""
+
+	req, err = http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(bytes.Repeat([]byte(" "), maxBodySize+1)))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	require.Empty(t, limiter)
+}
@@ -0,0 +1,343 @@
+package graphqlhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"log/slog"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/mwauth"
+	"song-library/internal/jobs"
+	"song-library/internal/library"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"strconv"
+	"strings"
+)
+
+// Error codes in the extensions of the errors
+const (
+	CodeBadUserInput    = "BAD_USER_INPUT"
+	CodeUnauthenticated = "UNAUTHENTICATED"
+	CodeForbidden       = "FORBIDDEN"
+	CodeNotFound        = "NOT_FOUND"
+	CodeAlreadyExists   = "ALREADY_EXISTS"
+	CodeInternal        = "INTERNAL"
+)
+
+var (
+	errUnauthenticated = newError(CodeUnauthenticated, "credentials are missing")
+	errForbidden       = newError(CodeForbidden, "role is not allowed")
+	errInternal        = newError(CodeInternal, "internal error")
+)
+
+// Error is returned to the client with the code in the extensions.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func newError(code string, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.Code}
+}
+
+type resolver struct {
+	log     *slog.Logger
+	storage Storage
+	jobs    jobs.Enqueuer
+}
+
+type songFilterInput struct {
+	Group       *string
+	Song        *string
+	ReleaseDate *string
+	Link        *string
+	LinkStatus  *string
+}
+
+type songInput struct {
+	Group string
+	Song  string
+}
+
+type songDetailInput struct {
+	ReleaseDate string
+	Text        string
+	Link        string
+}
+
+func (r *resolver) Songs(ctx context.Context, args struct {
+	Filter *songFilterInput
+	Page   int32
+	Limit  int32
+}) ([]*songResolver, error) {
+	if args.Page < 1 || args.Limit < 1 {
+		return nil, newError(CodeBadUserInput, "page and limit must be positive")
+	}
+
+	var filter models.SongWithDetail
+
+	if args.Filter != nil {
+		filter.GroupName = value(args.Filter.Group)
+		filter.SongName = value(args.Filter.Song)
+		filter.SongDetail.ReleaseDate = value(args.Filter.ReleaseDate)
+		filter.SongDetail.Link = value(args.Filter.Link)
+
+		if args.Filter.LinkStatus != nil {
+			filter.LinkCheck = &models.LinkCheck{Status: models.LinkStatus(strings.ToLower(*args.Filter.LinkStatus))}
+		}
+	}
+
+	songs, err := r.storage.SongsGet(ctx, filter, int(args.Page), int(args.Limit))
+	if err != nil && !errors.Is(err, storage.ErrSongNotFound) {
+		return nil, r.internal(ctx, "Failed to get songs", err)
+	}
+
+	return songResolvers(songs), nil
+}
+
+func (r *resolver) Groups(ctx context.Context, args struct {
+	Name  *string
+	Page  int32
+	Limit int32
+}) ([]*groupResolver, error) {
+	if args.Page < 1 || args.Limit < 1 {
+		return nil, newError(CodeBadUserInput, "page and limit must be positive")
+	}
+
+	groups, err := r.storage.Groups(ctx, value(args.Name), int(args.Page), int(args.Limit))
+	if err != nil {
+		return nil, r.internal(ctx, "Failed to get groups", err)
+	}
+
+	resolvers := make([]*groupResolver, 0, len(groups))
+	for _, group := range groups {
+		resolvers = append(resolvers, &groupResolver{name: group.Name})
+	}
+
+	return resolvers, nil
+}
+
+func (r *resolver) SaveSong(ctx context.Context, args struct{ Song songInput }) (*saveSongResolver, error) {
+	song, err := r.authorize(ctx, auth.RoleEditor, args.Song)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID, err := library.SaveSong(ctx, r.log, r.storage, r.jobs, song)
+	if err != nil {
+		if errors.Is(err, storage.ErrSongExists) {
+			return nil, newError(CodeAlreadyExists, "song already exists")
+		}
+
+		return nil, r.internal(ctx, "Failed to save song", err)
+	}
+
+	result := &saveSongResolver{song: &songResolver{song: models.SongWithDetail{Song: song}}}
+
+	// The song is saved even if the job is not enqueued, its details are set by updateSong then
+	if jobID == 0 {
+		return result, nil
+	}
+
+	id := graphql.ID(strconv.FormatInt(jobID, 10))
+	result.enrichJobID = &id
+
+	return result, nil
+}
+
+func (r *resolver) UpdateSong(ctx context.Context, args struct {
+	Song   songInput
+	Detail songDetailInput
+}) (bool, error) {
+	song, err := r.authorize(ctx, auth.RoleEditor, args.Song)
+	if err != nil {
+		return false, err
+	}
+
+	songDetail := models.SongDetail{
+		ReleaseDate: args.Detail.ReleaseDate,
+		Text:        args.Detail.Text,
+		Link:        args.Detail.Link,
+	}
+
+	err = r.storage.SongUpdate(ctx, song.GroupName, song.SongName, songDetail)
+	if err != nil {
+		if errors.Is(err, storage.ErrSongNotFound) {
+			return false, newError(CodeNotFound, "song not found")
+		}
+
+		return false, r.internal(ctx, "Failed to update song", err)
+	}
+
+	return true, nil
+}
+
+func (r *resolver) DeleteSong(ctx context.Context, args struct{ Song songInput }) (bool, error) {
+	song, err := r.authorize(ctx, auth.RoleAdmin, args.Song)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = r.storage.SongDelete(ctx, song.GroupName, song.SongName)
+	if err != nil {
+		if errors.Is(err, storage.ErrSongNotFound) {
+			return false, newError(CodeNotFound, "song not found")
+		}
+
+		return false, r.internal(ctx, "Failed to delete song", err)
+	}
+
+	return true, nil
+}
+
+// authorize checks the role of the client authenticated by mwauth and returns the song of the input.
+// The whole endpoint is public for reads, so the mutations check the roles of the REST routes themselves.
+func (r *resolver) authorize(ctx context.Context, role auth.Role, input songInput) (models.Song, error) {
+	principal, ok := mwauth.PrincipalFrom(ctx)
+	if !ok {
+		return models.Song{}, errUnauthenticated
+	}
+
+	if !principal.Has(role) {
+		r.log.InfoContext(ctx, "Forbidden: role is not allowed",
+			slog.String("subject", principal.Subject),
+			slog.String("subject_role", string(principal.Role)),
+			slog.String("role", string(role)))
+
+		return models.Song{}, errForbidden
+	}
+
+	if input.Group == "" || input.Song == "" {
+		return models.Song{}, newError(CodeBadUserInput, "group or song name is missing")
+	}
+
+	return models.Song{GroupName: input.Group, SongName: input.Song}, nil
+}
+
+// internal logs the error, the client gets the generic error.
+func (r *resolver) internal(ctx context.Context, msg string, err error) error {
+	r.log.ErrorContext(ctx, msg, slog.Any("error", err))
+
+	return errInternal
+}
+
+type groupResolver struct {
+	name string
+}
+
+func (g *groupResolver) Name() string {
+	return g.name
+}
+
+func (g *groupResolver) Songs(ctx context.Context, args struct{ Limit int32 }) ([]*songResolver, error) {
+	if args.Limit < 1 || args.Limit > maxGroupSongs {
+		return nil, newError(CodeBadUserInput, fmt.Sprintf("limit must be from 1 to %d", maxGroupSongs))
+	}
+
+	songs, err := loadGroupSongs(ctx, g.name, int(args.Limit))
+	if err != nil {
+		return nil, errInternal
+	}
+
+	return songResolvers(songs), nil
+}
+
+type songResolver struct {
+	song models.SongWithDetail
+}
+
+func songResolvers(songs []models.SongWithDetail) []*songResolver {
+	resolvers := make([]*songResolver, 0, len(songs))
+	for _, song := range songs {
+		resolvers = append(resolvers, &songResolver{song: song})
+	}
+
+	return resolvers
+}
+
+func (s *songResolver) Group() *groupResolver {
+	return &groupResolver{name: s.song.GroupName}
+}
+
+func (s *songResolver) Name() string {
+	return s.song.SongName
+}
+
+func (s *songResolver) Detail() *songDetailResolver {
+	return &songDetailResolver{detail: s.song.SongDetail}
+}
+
+func (s *songResolver) LinkCheck() *linkCheckResolver {
+	if s.song.LinkCheck == nil {
+		return nil
+	}
+
+	return &linkCheckResolver{linkCheck: *s.song.LinkCheck}
+}
+
+type songDetailResolver struct {
+	detail models.SongDetail
+}
+
+func (d *songDetailResolver) ReleaseDate() string {
+	return d.detail.ReleaseDate
+}
+
+func (d *songDetailResolver) Text() string {
+	return d.detail.Text
+}
+
+func (d *songDetailResolver) Link() string {
+	return d.detail.Link
+}
+
+type linkCheckResolver struct {
+	linkCheck models.LinkCheck
+}
+
+func (l *linkCheckResolver) Status() string {
+	return strings.ToUpper(string(l.linkCheck.Status))
+}
+
+func (l *linkCheckResolver) StatusCode() int32 {
+	return int32(l.linkCheck.StatusCode)
+}
+
+func (l *linkCheckResolver) RedirectTo() string {
+	return l.linkCheck.RedirectTo
+}
+
+func (l *linkCheckResolver) CheckedAt() graphql.Time {
+	return graphql.Time{Time: l.linkCheck.CheckedAt}
+}
+
+type saveSongResolver struct {
+	song        *songResolver
+	enrichJobID *graphql.ID
+}
+
+func (s *saveSongResolver) Song() *songResolver {
+	return s.song
+}
+
+func (s *saveSongResolver) EnrichJobId() *graphql.ID {
+	return s.enrichJobID
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
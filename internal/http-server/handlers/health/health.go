@@ -0,0 +1,105 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+)
+
+const (
+	statusOk          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+type Response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=ReadinessChecker
+type ReadinessChecker interface {
+	Ping(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+// State is the lifecycle state of the server shared with the readiness probe.
+type State struct {
+	shuttingDown atomic.Bool
+}
+
+// SetShuttingDown makes the readiness probe fail, so the orchestrator
+// stops sending new traffic while the server drains in-flight requests.
+func (s *State) SetShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s *State) ShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+// Live handles GET /healthz: the process is up and serves requests.
+func Live() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, Response{Status: statusOk})
+	}
+}
+
+// Ready handles GET /readyz: the server is not shutting down, the database is reachable
+// and its schema is migrated to the expected version.
+func Ready(log *slog.Logger, checker ReadinessChecker, state *State, expectedVersion uint) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.health.ready"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		resp := Response{Status: statusOk, Checks: make(map[string]string)}
+
+		fail := func(check string, reason string) {
+			resp.Status = statusUnavailable
+			resp.Checks[check] = reason
+		}
+
+		if state.ShuttingDown() {
+			fail("server", "shutting down")
+		} else {
+			resp.Checks["server"] = statusOk
+		}
+
+		if err := checker.Ping(r.Context()); err != nil {
+			log.ErrorContext(r.Context(), "Database is unavailable", slog.Any("error", err))
+
+			fail("database", "unreachable")
+		} else {
+			resp.Checks["database"] = statusOk
+		}
+
+		version, dirty, err := checker.SchemaVersion(r.Context())
+		switch {
+		case err != nil:
+			log.ErrorContext(r.Context(), "Failed to get schema version", slog.Any("error", err))
+
+			fail("migrations", "unknown version")
+		case dirty:
+			fail("migrations", fmt.Sprintf("version %d is dirty", version))
+		case version != expectedVersion:
+			fail("migrations", fmt.Sprintf("version %d, expected %d", version, expectedVersion))
+		default:
+			resp.Checks["migrations"] = statusOk
+		}
+
+		if resp.Status != statusOk {
+			log.InfoContext(r.Context(), "Server is not ready", slog.Any("checks", resp.Checks))
+
+			render.Status(r, http.StatusServiceUnavailable)
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
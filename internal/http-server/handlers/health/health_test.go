@@ -0,0 +1,98 @@
+package health
+
+import (
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/http-server/handlers/health/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"testing"
+)
+
+func TestReadyHandler(t *testing.T) {
+	const expectedVersion = 3
+
+	cases := []struct {
+		name         string
+		shuttingDown bool
+		pingError    error
+		version      uint
+		dirty        bool
+		versionError error
+		httpStatus   int
+	}{
+		{
+			name:       "Ready",
+			version:    expectedVersion,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:         "Shutting down",
+			shuttingDown: true,
+			version:      expectedVersion,
+			httpStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:       "Database unavailable",
+			pingError:  errors.New("connection refused"),
+			version:    expectedVersion,
+			httpStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "Old schema",
+			version:    expectedVersion - 1,
+			httpStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "Dirty schema",
+			version:    expectedVersion,
+			dirty:      true,
+			httpStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:         "Schema version error",
+			versionError: errors.New("internal error"),
+			httpStatus:   http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			checkerMock := mocks.NewReadinessChecker(t)
+			checkerMock.On("Ping", mock.Anything).Return(tc.pingError)
+			checkerMock.On("SchemaVersion", mock.Anything).Return(tc.version, tc.dirty, tc.versionError)
+
+			state := &State{}
+			if tc.shuttingDown {
+				state.SetShuttingDown()
+			}
+
+			handler := Ready(slogdiscard.NewDiscardLogger(), checkerMock, state, expectedVersion)
+
+			req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.httpStatus, rr.Code)
+		})
+	}
+}
+
+func TestLiveHandler(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	Live().ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"status":"ok"}`, rr.Body.String())
+}
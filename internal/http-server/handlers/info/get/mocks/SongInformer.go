@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	models "song-library/internal/models"
 
 	mock "github.com/stretchr/testify/mock"
@@ -13,9 +14,9 @@ type SongInformer struct {
 	mock.Mock
 }
 
-// SongInfo provides a mock function with given fields: groupName, songName
-func (_m *SongInformer) SongInfo(groupName string, songName string) (models.SongDetail, error) {
-	ret := _m.Called(groupName, songName)
+// SongInfo provides a mock function with given fields: ctx, groupName, songName
+func (_m *SongInformer) SongInfo(ctx context.Context, groupName string, songName string) (models.SongDetail, error) {
+	ret := _m.Called(ctx, groupName, songName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SongInfo")
@@ -23,17 +24,17 @@ func (_m *SongInformer) SongInfo(groupName string, songName string) (models.Song
 
 	var r0 models.SongDetail
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (models.SongDetail, error)); ok {
-		return rf(groupName, songName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (models.SongDetail, error)); ok {
+		return rf(ctx, groupName, songName)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) models.SongDetail); ok {
-		r0 = rf(groupName, songName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) models.SongDetail); ok {
+		r0 = rf(ctx, groupName, songName)
 	} else {
 		r0 = ret.Get(0).(models.SongDetail)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(groupName, songName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupName, songName)
 	} else {
 		r1 = ret.Error(1)
 	}
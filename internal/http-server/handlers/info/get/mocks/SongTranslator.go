@@ -0,0 +1,59 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "song-library/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SongTranslator is an autogenerated mock type for the SongTranslator type
+type SongTranslator struct {
+	mock.Mock
+}
+
+// Translations provides a mock function with given fields: ctx, groupName, songName
+func (_m *SongTranslator) Translations(ctx context.Context, groupName string, songName string) ([]models.SongTranslation, error) {
+	ret := _m.Called(ctx, groupName, songName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Translations")
+	}
+
+	var r0 []models.SongTranslation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.SongTranslation, error)); ok {
+		return rf(ctx, groupName, songName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.SongTranslation); ok {
+		r0 = rf(ctx, groupName, songName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SongTranslation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupName, songName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewSongTranslator creates a new instance of SongTranslator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSongTranslator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SongTranslator {
+	mock := &SongTranslator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,27 +1,33 @@
 package songinfo
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
-	"net/url"
+	"song-library/internal/http-server/httpcache"
 	"song-library/internal/models"
 	"song-library/internal/storage"
+	"song-library/internal/translation"
 )
 
 //go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=SongInformer
 type SongInformer interface {
-	SongInfo(groupName string, songName string) (models.SongDetail, error)
+	SongInfo(ctx context.Context, groupName string, songName string) (models.SongDetail, error)
 }
 
-func New(log *slog.Logger, songInformer SongInformer) http.HandlerFunc {
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=SongTranslator
+type SongTranslator interface {
+	Translations(ctx context.Context, groupName string, songName string) ([]models.SongTranslation, error)
+}
+
+func New(log *slog.Logger, songInformer SongInformer, songTranslator SongTranslator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.info.get"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -30,7 +36,7 @@ func New(log *slog.Logger, songInformer SongInformer) http.HandlerFunc {
 		songName := r.URL.Query().Get("song")
 
 		if groupName == "" || songName == "" {
-			log.Info("Bad request: get parameter 'group' or 'song' is missing",
+			log.InfoContext(r.Context(), "Bad request: get parameter 'group' or 'song' is missing",
 				slog.String("group", groupName),
 				slog.String("song", songName))
 
@@ -38,14 +44,16 @@ func New(log *slog.Logger, songInformer SongInformer) http.HandlerFunc {
 			return
 		}
 
-		log.Info("Start request GET /info",
+		log.InfoContext(r.Context(), "Start request GET /info",
 			slog.String("group", groupName),
-			slog.String("song", songName))
+			slog.String("song", songName),
+			slog.String("lang", r.URL.Query().Get("lang")),
+			slog.String("accept_language", r.Header.Get("Accept-Language")))
 
-		songDetail, err := songInformer.SongInfo(groupName, songName)
+		songDetail, err := songInformer.SongInfo(r.Context(), groupName, songName)
 		if err != nil {
 			if errors.Is(err, storage.ErrSongNotFound) {
-				log.Info("Song not found",
+				log.InfoContext(r.Context(), "Song not found",
 					slog.String("group", groupName),
 					slog.String("song", songName))
 
@@ -53,56 +61,38 @@ func New(log *slog.Logger, songInformer SongInformer) http.HandlerFunc {
 				return
 			}
 
-			log.Error("Failed to find song", slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Failed to find song", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusInternalServerError)
 
 			return
 		}
 
-		render.JSON(w, r, songDetail)
-	}
-}
-
-var ErrSongNotFound = errors.New("song not found")
-
-func GetInfoSongDetail(cfgHost string, groupName string, songName string) (songDetail models.SongDetail, err error) {
-	queryParameters := url.Values{}
-	queryParameters.Add("group", groupName)
-	queryParameters.Add("song", songName)
-
-	getURL := url.URL{
-		Scheme:   "http",
-		Host:     cfgHost,
-		Path:     "info",
-		RawQuery: queryParameters.Encode(),
-	}
-
-	resp, err := http.Get(getURL.String())
-	if err != nil {
-		return songDetail, err
-	}
+		// The response depends on the requested language
+		w.Header().Add("Vary", "Accept-Language")
 
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+		lang, err := translation.Translate(r.Context(), songTranslator, models.Song{GroupName: groupName, SongName: songName},
+			&songDetail, translation.RequestedFromRequest(r))
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to find song translations", slog.Any("error", err))
 
-	if resp.StatusCode == http.StatusOK {
+			w.WriteHeader(http.StatusInternalServerError)
 
-		err := json.NewDecoder(resp.Body).Decode(&songDetail)
-		if err != nil {
-			return songDetail, err
+			return
 		}
 
-		// Song found
-		return songDetail, nil
+		if lang != "" {
+			log.DebugContext(r.Context(), "Song translation found", slog.String("lang", lang))
 
-	} else if resp.StatusCode == http.StatusNoContent {
-
-		return songDetail, ErrSongNotFound
+			w.Header().Set("Content-Language", lang)
+		}
 
-	} else {
+		// Translations change the modification time of the song
+		if httpcache.NotModified(w, r, songDetail.UpdatedAt) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
 
-		return songDetail, errors.New("undefined return code from GET /info")
+		render.JSON(w, r, songDetail)
 	}
 }
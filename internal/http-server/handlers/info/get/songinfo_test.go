@@ -1,7 +1,9 @@
 package songinfo
 
 import (
+	"encoding/json"
 	"errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +13,7 @@ import (
 	"song-library/internal/models"
 	"song-library/internal/storage"
 	"testing"
+	"time"
 )
 
 func TestSongInfoHandler(t *testing.T) {
@@ -73,10 +76,10 @@ func TestSongInfoHandler(t *testing.T) {
 
 			songInformerMock := mocks.NewSongInformer(t)
 
-			songInformerMock.On("SongInfo", tc.groupName, tc.songName).
+			songInformerMock.On("SongInfo", mock.Anything, tc.groupName, tc.songName).
 				Return(models.SongDetail{}, tc.mockError).Maybe()
 
-			handler := New(slogdiscard.NewDiscardLogger(), songInformerMock)
+			handler := New(slogdiscard.NewDiscardLogger(), songInformerMock, mocks.NewSongTranslator(t))
 
 			queryParameters := url.Values{}
 			queryParameters.Add("group", tc.groupName)
@@ -96,3 +99,142 @@ func TestSongInfoHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestSongInfoHandler_Translation(t *testing.T) {
+	translations := []models.SongTranslation{
+		{Lang: "de", Text: "deutscher Text"},
+		{Lang: "ru", Text: "русский текст"},
+	}
+
+	cases := []struct {
+		name            string
+		lang            string
+		acceptLanguage  string
+		text            string
+		contentLanguage string
+	}{
+		{
+			name:            "Lang parameter",
+			lang:            "ru",
+			text:            "русский текст",
+			contentLanguage: "ru",
+		},
+		{
+			name:            "Lang parameter with region",
+			lang:            "de-AT",
+			text:            "deutscher Text",
+			contentLanguage: "de",
+		},
+		{
+			name:            "Accept-Language",
+			acceptLanguage:  "fr;q=0.9, de;q=0.8",
+			text:            "deutscher Text",
+			contentLanguage: "de",
+		},
+		{
+			name:            "Lang parameter takes precedence",
+			lang:            "ru",
+			acceptLanguage:  "de",
+			text:            "русский текст",
+			contentLanguage: "ru",
+		},
+		{
+			name: "No translation",
+			lang: "fr",
+			text: "original text",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			songInformerMock := mocks.NewSongInformer(t)
+			songInformerMock.On("SongInfo", mock.Anything, "test_group", "test_song").
+				Return(models.SongDetail{Text: "original text"}, nil)
+
+			songTranslatorMock := mocks.NewSongTranslator(t)
+			songTranslatorMock.On("Translations", mock.Anything, "test_group", "test_song").
+				Return(translations, nil)
+
+			handler := New(slogdiscard.NewDiscardLogger(), songInformerMock, songTranslatorMock)
+
+			queryParameters := url.Values{}
+			queryParameters.Add("group", "test_group")
+			queryParameters.Add("song", "test_song")
+			if tc.lang != "" {
+				queryParameters.Add("lang", tc.lang)
+			}
+
+			songURL := url.URL{Path: "/info",
+				RawQuery: queryParameters.Encode()}
+
+			req, err := http.NewRequest(http.MethodGet, songURL.String(), nil)
+			require.NoError(t, err)
+
+			if tc.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			require.Equal(t, tc.contentLanguage, rr.Header().Get("Content-Language"))
+
+			var songDetail models.SongDetail
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &songDetail))
+			require.Equal(t, tc.text, songDetail.Text)
+		})
+	}
+}
+
+func TestSongInfoHandler_NotModified(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 12, 30, 15, 0, time.UTC)
+
+	cases := []struct {
+		name            string
+		ifModifiedSince string
+		httpStatus      int
+	}{
+		{
+			name:       "Without If-Modified-Since",
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:            "Not modified",
+			ifModifiedSince: "Wed, 01 May 2024 12:30:15 GMT",
+			httpStatus:      http.StatusNotModified,
+		},
+		{
+			name:            "Modified",
+			ifModifiedSince: "Wed, 01 May 2024 12:00:00 GMT",
+			httpStatus:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			songInformerMock := mocks.NewSongInformer(t)
+
+			songInformerMock.On("SongInfo", mock.Anything, "test_group", "test_song").
+				Return(models.SongDetail{Text: "text", UpdatedAt: updatedAt}, nil)
+
+			handler := New(slogdiscard.NewDiscardLogger(), songInformerMock, mocks.NewSongTranslator(t))
+
+			req, err := http.NewRequest(http.MethodGet, "/info?group=test_group&song=test_song", nil)
+			require.NoError(t, err)
+			if tc.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tc.ifModifiedSince)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.httpStatus, rr.Code)
+			require.Equal(t, "Wed, 01 May 2024 12:30:15 GMT", rr.Header().Get("Last-Modified"))
+		})
+	}
+}
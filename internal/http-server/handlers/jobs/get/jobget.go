@@ -0,0 +1,61 @@
+package jobget
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"strconv"
+)
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=JobGetter
+type JobGetter interface {
+	Job(ctx context.Context, jobID int64) (models.Job, error)
+}
+
+// New returns the handler of GET /jobs/{id}, the status of a background job.
+func New(log *slog.Logger, jobGetter JobGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.jobs.get"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id := chi.URLParam(r, "id")
+
+		jobID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil || jobID < 1 {
+			log.InfoContext(r.Context(), "Bad request: job id is incorrect", slog.String("id", id))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		job, err := jobGetter.Job(r.Context(), jobID)
+		if err != nil {
+			if errors.Is(err, storage.ErrJobNotFound) {
+				log.InfoContext(r.Context(), "Job not found", slog.Int64("job_id", jobID))
+
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			log.ErrorContext(r.Context(), "Failed to get job", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		render.JSON(w, r, job)
+	}
+}
@@ -0,0 +1,86 @@
+package jobget
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/http-server/handlers/jobs/get/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"testing"
+)
+
+func TestJobGetHandler(t *testing.T) {
+	job := models.Job{
+		ID:          7,
+		Kind:        "song.enrich",
+		Payload:     json.RawMessage(`{"group":"Muse","song":"Uprising"}`),
+		Status:      models.JobPending,
+		Attempts:    1,
+		MaxAttempts: 5,
+		LastError:   "service unavailable",
+	}
+
+	cases := []struct {
+		name       string
+		id         string
+		mockError  error
+		httpStatus int
+	}{
+		{
+			name:       "Success",
+			id:         "7",
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "Incorrect id",
+			id:         "seven",
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Job not found",
+			id:         "7",
+			mockError:  storage.ErrJobNotFound,
+			httpStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Storage error",
+			id:         "7",
+			mockError:  errors.New("internal error"),
+			httpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			jobGetterMock := mocks.NewJobGetter(t)
+
+			jobGetterMock.On("Job", mock.Anything, int64(7)).
+				Return(job, tc.mockError).Maybe()
+
+			router := chi.NewRouter()
+			router.Get("/jobs/{id}", New(slogdiscard.NewDiscardLogger(), jobGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, "/jobs/"+tc.id, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.httpStatus, rr.Code)
+
+			if tc.httpStatus == http.StatusOK {
+				var resp models.Job
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, job, resp)
+			}
+		})
+	}
+}
@@ -2,16 +2,20 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // SongDeleter is an autogenerated mock type for the SongDeleter type
 type SongDeleter struct {
 	mock.Mock
 }
 
-// SongDelete provides a mock function with given fields: groupName, songName
-func (_m *SongDeleter) SongDelete(groupName string, songName string) (int, error) {
-	ret := _m.Called(groupName, songName)
+// SongDelete provides a mock function with given fields: ctx, groupName, songName
+func (_m *SongDeleter) SongDelete(ctx context.Context, groupName string, songName string) (int, error) {
+	ret := _m.Called(ctx, groupName, songName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SongDelete")
@@ -19,17 +23,17 @@ func (_m *SongDeleter) SongDelete(groupName string, songName string) (int, error
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (int, error)); ok {
-		return rf(groupName, songName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return rf(ctx, groupName, songName)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) int); ok {
-		r0 = rf(groupName, songName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = rf(ctx, groupName, songName)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(groupName, songName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, groupName, songName)
 	} else {
 		r1 = ret.Error(1)
 	}
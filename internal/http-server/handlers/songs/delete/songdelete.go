@@ -1,6 +1,7 @@
 package songdelete
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -12,14 +13,14 @@ import (
 
 //go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=SongDeleter
 type SongDeleter interface {
-	SongDelete(groupName string, songName string) (songId int, err error)
+	SongDelete(ctx context.Context, groupName string, songName string) (songId int, err error)
 }
 
 func New(log *slog.Logger, songDeleter SongDeleter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.songs.delete"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -28,17 +29,17 @@ func New(log *slog.Logger, songDeleter SongDeleter) http.HandlerFunc {
 
 		err := render.DecodeJSON(r.Body, &req)
 		if err != nil {
-			log.Error("Filed to decode request body", slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Filed to decode request body", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusBadRequest)
 
 			return
 		}
 
-		log.Info("Request body decoded", slog.Any("request", req))
+		log.InfoContext(r.Context(), "Request body decoded", slog.Any("request", req))
 
 		if req.GroupName == "" || req.SongName == "" {
-			log.Info("Cannot delete song, group or song name is missing",
+			log.InfoContext(r.Context(), "Cannot delete song, group or song name is missing",
 				slog.String("song", req.SongName),
 				slog.String("group", req.GroupName))
 
@@ -47,10 +48,10 @@ func New(log *slog.Logger, songDeleter SongDeleter) http.HandlerFunc {
 			return
 		}
 
-		songId, err := songDeleter.SongDelete(req.GroupName, req.SongName)
+		songId, err := songDeleter.SongDelete(r.Context(), req.GroupName, req.SongName)
 		if err != nil {
 			if errors.Is(err, storage.ErrSongNotFound) {
-				log.Info("SongName not found",
+				log.InfoContext(r.Context(), "SongName not found",
 					slog.String("song", req.SongName),
 					slog.String("group", req.GroupName))
 
@@ -59,14 +60,14 @@ func New(log *slog.Logger, songDeleter SongDeleter) http.HandlerFunc {
 				return
 			}
 
-			log.Error("Failed to delete song", slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Failed to delete song", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusInternalServerError)
 
 			return
 		}
 
-		log.Info("SongName successfully deleted",
+		log.InfoContext(r.Context(), "SongName successfully deleted",
 			slog.String("group", req.GroupName),
 			slog.String("song", req.SongName),
 			slog.Int("song_id", songId),
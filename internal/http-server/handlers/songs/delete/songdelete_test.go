@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
@@ -74,7 +75,7 @@ func TestSongDeleteHandler(t *testing.T) {
 
 			songDeleterMock := mocks.NewSongDeleter(t)
 
-			songDeleterMock.On("SongDelete", tc.groupName, tc.songName).
+			songDeleterMock.On("SongDelete", mock.Anything, tc.groupName, tc.songName).
 				Return(0, tc.mockError).Maybe()
 
 			handler := New(slogdiscard.NewDiscardLogger(), songDeleterMock)
@@ -1,14 +1,18 @@
 package songsget
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"slices"
+	"song-library/internal/http-server/httpcache"
 	"song-library/internal/models"
 	"song-library/internal/storage"
 	"strconv"
+	"time"
 )
 
 type SongsResponse struct {
@@ -18,15 +22,19 @@ type SongsResponse struct {
 	Items int                     `json:"items"` // len(songs)
 }
 
+// linkStatuses are the values of the link_status filter
+var linkStatuses = []models.LinkStatus{models.LinkOK, models.LinkBroken, models.LinkUnreachable, models.LinkUnchecked}
+
 type SongsGetter interface {
-	SongsGet(filter models.SongWithDetail, page int, limit int) ([]models.SongWithDetail, error)
+	SongsGet(ctx context.Context, filter models.SongWithDetail, page int, limit int) ([]models.SongWithDetail, error)
+	SongsUpdatedAt(ctx context.Context) (time.Time, error)
 }
 
 func New(log *slog.Logger, songsGetter SongsGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.songs.get"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -35,20 +43,22 @@ func New(log *slog.Logger, songsGetter SongsGetter) http.HandlerFunc {
 		songName := r.URL.Query().Get("song")
 		releaseDate := r.URL.Query().Get("date")
 		link := r.URL.Query().Get("link")
+		linkStatus := r.URL.Query().Get("link_status")
 		page := r.URL.Query().Get("page")
 		limit := r.URL.Query().Get("limit")
 
-		log.Info("Start request GET /songs",
+		log.InfoContext(r.Context(), "Start request GET /songs",
 			slog.String("group", groupName),
 			slog.String("song", songName),
 			slog.String("releaseDate", releaseDate),
 			slog.String("link", link),
+			slog.String("link_status", linkStatus),
 			slog.String("page", page),
 			slog.String("limit", limit))
 
 		pageNumber, err := strconv.Atoi(page)
 		if err != nil || pageNumber < 1 {
-			log.Info("Bad request: get parameter 'page' is incorrect",
+			log.InfoContext(r.Context(), "Bad request: get parameter 'page' is incorrect",
 				slog.String("page", page))
 
 			w.WriteHeader(http.StatusBadRequest)
@@ -57,13 +67,21 @@ func New(log *slog.Logger, songsGetter SongsGetter) http.HandlerFunc {
 
 		intLimit, err := strconv.Atoi(limit)
 		if err != nil || intLimit < 1 {
-			log.Info("Bad request: get parameter 'limit' is incorrect",
+			log.InfoContext(r.Context(), "Bad request: get parameter 'limit' is incorrect",
 				slog.String("limit", limit))
 
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
+		if linkStatus != "" && !slices.Contains(linkStatuses, models.LinkStatus(linkStatus)) {
+			log.InfoContext(r.Context(), "Bad request: get parameter 'link_status' is incorrect",
+				slog.String("link_status", linkStatus))
+
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		var filter models.SongWithDetail
 
 		filter.GroupName = groupName
@@ -71,10 +89,30 @@ func New(log *slog.Logger, songsGetter SongsGetter) http.HandlerFunc {
 		filter.SongDetail.ReleaseDate = releaseDate
 		filter.SongDetail.Link = link
 
-		songs, err := songsGetter.SongsGet(filter, pageNumber, intLimit)
+		if linkStatus != "" {
+			filter.LinkCheck = &models.LinkCheck{Status: models.LinkStatus(linkStatus)}
+		}
+
+		// Any change of the library may change the list, it is checked before the songs are queried
+		updatedAt, err := songsGetter.SongsUpdatedAt(r.Context())
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to get library modification time", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		if httpcache.NotModified(w, r, updatedAt) {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		songs, err := songsGetter.SongsGet(r.Context(), filter, pageNumber, intLimit)
 		if err != nil {
 			if errors.Is(err, storage.ErrSongNotFound) {
-				log.Info("SongName not found",
+				log.InfoContext(r.Context(), "SongName not found",
 					slog.String("group", groupName),
 					slog.String("song", songName))
 
@@ -83,7 +121,7 @@ func New(log *slog.Logger, songsGetter SongsGetter) http.HandlerFunc {
 				return
 			}
 
-			log.Error("Failed to get songs", slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Failed to get songs", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusInternalServerError)
 
@@ -1,24 +1,36 @@
 package songsave
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
-	songinfo "song-library/internal/http-server/handlers/info/get"
+	"song-library/internal/jobs"
+	"song-library/internal/library"
 	"song-library/internal/models"
+	"song-library/internal/storage"
 )
 
 type SongSaver interface {
-	SaveSong(groupName string, songName string) (songId int, err error)
+	SongInfo(ctx context.Context, groupName string, songName string) (models.SongDetail, error)
+	SaveSong(ctx context.Context, groupName string, songName string) (songId int, err error)
 }
 
-func New(log *slog.Logger, songSaver SongSaver, cfgHost string) http.HandlerFunc {
+// SaveResponse is sent if the enrichment job of the saved song is enqueued,
+// its status is served by GET /jobs/{id}.
+type SaveResponse struct {
+	EnrichJobID int64 `json:"enrichJobId"`
+}
+
+// New returns the handler of POST /songs. If jobEnqueuer is not nil,
+// a job filling the details of the saved song from the music info service is enqueued.
+func New(log *slog.Logger, songSaver SongSaver, jobEnqueuer jobs.Enqueuer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.songs.save"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -27,17 +39,17 @@ func New(log *slog.Logger, songSaver SongSaver, cfgHost string) http.HandlerFunc
 
 		err := render.DecodeJSON(r.Body, &req)
 		if err != nil {
-			log.Error("Filed to decode request body", slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Filed to decode request body", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusBadRequest)
 
 			return
 		}
 
-		log.Info("Request body decoded", slog.Any("request", req))
+		log.InfoContext(r.Context(), "Request body decoded", slog.Any("request", req))
 
 		if req.GroupName == "" || req.SongName == "" {
-			log.Info("Cannot save song, group or song name is missing",
+			log.InfoContext(r.Context(), "Cannot save song, group or song name is missing",
 				slog.String("song", req.SongName),
 				slog.String("group", req.GroupName))
 
@@ -46,50 +58,30 @@ func New(log *slog.Logger, songSaver SongSaver, cfgHost string) http.HandlerFunc
 			return
 		}
 
-		_, err = songinfo.GetInfoSongDetail(cfgHost, req.GroupName, req.SongName)
-
-		if err == nil {
-			log.Info("Song already exists",
-				slog.String("group", req.GroupName),
-				slog.String("song", req.SongName))
-
-			w.WriteHeader(http.StatusAlreadyReported)
-
-			return
+		jobID, err := library.SaveSong(r.Context(), log, songSaver, jobEnqueuer, req)
+		if err != nil {
+			if errors.Is(err, storage.ErrSongExists) {
+				log.InfoContext(r.Context(), "Song already exists",
+					slog.String("group", req.GroupName),
+					slog.String("song", req.SongName))
 
-		}
+				w.WriteHeader(http.StatusAlreadyReported)
+				return
+			}
 
-		if !errors.Is(err, songinfo.ErrSongNotFound) {
-			log.Error("Failed GET /info",
-				slog.String("group", req.GroupName),
-				slog.String("song", req.SongName),
-				slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Failed to save song", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusInternalServerError)
-
 			return
 		}
 
-		log.Debug("Start to save new song",
-			slog.String("group", req.GroupName),
-			slog.String("song", req.SongName))
-
-		songId, err := songSaver.SaveSong(req.GroupName, req.SongName)
-		if err != nil {
-
-			log.Error("Failed to save song", slog.Any("error", err))
-
-			w.WriteHeader(http.StatusInternalServerError)
-
+		// The song is saved even if the job is not enqueued, its details are set by PUT /songs then
+		if jobID == 0 {
+			w.WriteHeader(http.StatusCreated)
 			return
 		}
 
-		log.Info("Song successfully saved",
-			slog.String("group", req.GroupName),
-			slog.String("song", req.SongName),
-			slog.Int("song_id", songId),
-		)
-
-		w.WriteHeader(http.StatusCreated)
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, SaveResponse{EnrichJobID: jobID})
 	}
 }
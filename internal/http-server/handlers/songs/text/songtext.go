@@ -1,28 +1,43 @@
 package songtext
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
-	songinfo "song-library/internal/http-server/handlers/info/get"
+	"song-library/internal/http-server/httpcache"
+	"song-library/internal/models"
+	"song-library/internal/songtext"
+	"song-library/internal/storage"
+	"song-library/internal/translation"
 	"strconv"
-	"strings"
 )
 
+type SongInformer interface {
+	SongInfo(ctx context.Context, groupName string, songName string) (models.SongDetail, error)
+}
+
 type Response struct {
-	GroupName string `json:"group"`
-	SongName  string `json:"song"`
-	SongText  string `json:"text"`
-	Page      int    `json:"page"`
+	GroupName  string `json:"group"`
+	SongName   string `json:"song"`
+	SongText   string `json:"text"`
+	Page       int    `json:"page"`
+	TotalPages int    `json:"totalPages"`
+	// Lang is the language of the translation, empty for the original text
+	Lang string `json:"lang,omitempty"`
 }
 
-func New(log *slog.Logger, cfgHost string) http.HandlerFunc {
+// HeaderTotalPages is set on every response with a song text,
+// including 204 for out-of-range pages.
+const HeaderTotalPages = "X-Total-Pages"
+
+func New(log *slog.Logger, songInformer SongInformer, songTranslator translation.Translator) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.songs.songText"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -30,23 +45,69 @@ func New(log *slog.Logger, cfgHost string) http.HandlerFunc {
 		groupName := r.URL.Query().Get("group")
 		songName := r.URL.Query().Get("song")
 		page := r.URL.Query().Get("page")
+		by := r.URL.Query().Get("by")
+		size := r.URL.Query().Get("size")
+		unique := r.URL.Query().Get("unique")
+		lang := r.URL.Query().Get("lang")
+		acceptLanguage := r.Header.Get("Accept-Language")
 
-		log.Info("Start request GET /songs/text",
+		log.InfoContext(r.Context(), "Start request GET /songs/text",
 			slog.String("group", groupName),
 			slog.String("song", songName),
-			slog.String("page", page))
+			slog.String("page", page),
+			slog.String("by", by),
+			slog.String("size", size),
+			slog.String("unique", unique),
+			slog.String("lang", lang),
+			slog.String("accept_language", acceptLanguage))
 
 		pageNumber, err := strconv.Atoi(page)
 		if err != nil || pageNumber < 1 {
-			log.Info("Bad request: get parameter 'page' is incorrect",
+			log.InfoContext(r.Context(), "Bad request: get parameter 'page' is incorrect",
 				slog.String("page", page))
 
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
+		if by == "" {
+			by = songtext.ByVerse
+		}
+
+		if by != songtext.ByVerse && by != songtext.ByLine && by != songtext.ByChars {
+			log.InfoContext(r.Context(), "Bad request: get parameter 'by' is incorrect",
+				slog.String("by", by))
+
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		pageSize := songtext.DefaultPageSize(by)
+		if size != "" {
+			pageSize, err = strconv.Atoi(size)
+			if err != nil || pageSize < 1 {
+				log.InfoContext(r.Context(), "Bad request: get parameter 'size' is incorrect",
+					slog.String("size", size))
+
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		uniqueVerses := false
+		if unique != "" {
+			uniqueVerses, err = strconv.ParseBool(unique)
+			if err != nil {
+				log.InfoContext(r.Context(), "Bad request: get parameter 'unique' is incorrect",
+					slog.String("unique", unique))
+
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
 		if groupName == "" || songName == "" {
-			log.Info("Bad request: get parameter 'group' or 'song' is missing",
+			log.InfoContext(r.Context(), "Bad request: get parameter 'group' or 'song' is missing",
 				slog.String("group", groupName),
 				slog.String("song", songName))
 
@@ -54,10 +115,10 @@ func New(log *slog.Logger, cfgHost string) http.HandlerFunc {
 			return
 		}
 
-		songDetail, err := songinfo.GetInfoSongDetail(cfgHost, groupName, songName)
+		songDetail, err := songInformer.SongInfo(r.Context(), groupName, songName)
 		if err != nil {
-			if errors.Is(err, songinfo.ErrSongNotFound) {
-				log.Info("Song not found",
+			if errors.Is(err, storage.ErrSongNotFound) {
+				log.InfoContext(r.Context(), "Song not found",
 					slog.String("group", groupName),
 					slog.String("song", songName))
 
@@ -65,33 +126,59 @@ func New(log *slog.Logger, cfgHost string) http.HandlerFunc {
 				return
 			}
 
-			log.Error("Failed GET /info",
-				slog.String("group", groupName),
-				slog.String("song", songName),
-				slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Failed to find song", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		textLang, err := translation.Translate(r.Context(), songTranslator,
+			models.Song{GroupName: groupName, SongName: songName}, &songDetail, translation.Requested(lang, acceptLanguage))
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to find song translations", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		// Song text pagination
-		verseSlice := strings.Split(songDetail.Text, "\n\n")
+		pages, err := songtext.Paginate(songDetail.Text, by, pageSize, uniqueVerses)
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to paginate song text", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set(HeaderTotalPages, strconv.Itoa(len(pages)))
+		w.Header().Add("Vary", "Accept-Language")
+		if textLang != "" {
+			w.Header().Set("Content-Language", textLang)
+		}
+
+		if httpcache.NotModified(w, r, songDetail.UpdatedAt) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
 
-		if pageNumber > len(verseSlice) {
-			log.Info("The song text has no this page",
+		if pageNumber > len(pages) {
+			log.InfoContext(r.Context(), "The song text has no this page",
 				slog.String("group", groupName),
 				slog.String("song", songName),
-				slog.Int("page", pageNumber))
+				slog.Int("page", pageNumber),
+				slog.Int("total_pages", len(pages)))
 
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
 
 		render.JSON(w, r, Response{
-			GroupName: groupName,
-			SongName:  songName,
-			SongText:  verseSlice[pageNumber-1],
-			Page:      pageNumber,
+			GroupName:  groupName,
+			SongName:   songName,
+			SongText:   pages[pageNumber-1],
+			Page:       pageNumber,
+			TotalPages: len(pages),
+			Lang:       textLang,
 		})
 	}
 }
@@ -1,6 +1,7 @@
 package songupdate
 
 import (
+	"context"
 	"errors"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -11,14 +12,14 @@ import (
 )
 
 type SongUpdater interface {
-	SongUpdate(groupName string, songName string, songDetail models.SongDetail) error
+	SongUpdate(ctx context.Context, groupName string, songName string, songDetail models.SongDetail) error
 }
 
 func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.songs.update"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -27,7 +28,7 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 
 		err := render.DecodeJSON(r.Body, &req)
 		if err != nil {
-			log.Error("Filed to decode request body", slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Filed to decode request body", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusBadRequest)
 
@@ -40,12 +41,12 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 			return
 		}
 
-		log.Info("Request body decoded", slog.Any("request", req))
+		log.InfoContext(r.Context(), "Request body decoded", slog.Any("request", req))
 
-		err = songUpdater.SongUpdate(req.GroupName, req.SongName, req.SongDetail)
+		err = songUpdater.SongUpdate(r.Context(), req.GroupName, req.SongName, req.SongDetail)
 		if err != nil {
 			if errors.Is(err, storage.ErrSongNotFound) {
-				log.Info("SongName not found",
+				log.InfoContext(r.Context(), "SongName not found",
 					slog.String("group", req.GroupName),
 					slog.String("song", req.SongName))
 
@@ -54,7 +55,7 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 				return
 			}
 
-			log.Error("Failed to update song", slog.Any("error", err))
+			log.ErrorContext(r.Context(), "Failed to update song", slog.Any("error", err))
 
 			w.WriteHeader(http.StatusInternalServerError)
 
@@ -62,7 +63,7 @@ func New(log *slog.Logger, songUpdater SongUpdater) http.HandlerFunc {
 
 		}
 
-		log.Info("SongName successfully updated",
+		log.InfoContext(r.Context(), "SongName successfully updated",
 			slog.String("group", req.GroupName),
 			slog.String("song", req.SongName),
 		)
@@ -0,0 +1,74 @@
+package translationsget
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"song-library/internal/http-server/httpcache"
+	"song-library/internal/models"
+)
+
+type TranslationsResponse struct {
+	Translations []models.SongTranslation `json:"translations"`
+	Items        int                      `json:"items"` // len(translations)
+}
+
+type TranslationsGetter interface {
+	Translations(ctx context.Context, groupName string, songName string) ([]models.SongTranslation, error)
+}
+
+func New(log *slog.Logger, translationsGetter TranslationsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.translations.get"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		groupName := r.URL.Query().Get("group")
+		songName := r.URL.Query().Get("song")
+
+		if groupName == "" || songName == "" {
+			log.InfoContext(r.Context(), "Bad request: get parameter 'group' or 'song' is missing",
+				slog.String("group", groupName),
+				slog.String("song", songName))
+
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		log.InfoContext(r.Context(), "Start request GET /songs/translations",
+			slog.String("group", groupName),
+			slog.String("song", songName))
+
+		translations, err := translationsGetter.Translations(r.Context(), groupName, songName)
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to get translations", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		if len(translations) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		// All translations have the modification time of the song
+		if httpcache.NotModified(w, r, translations[0].UpdatedAt) {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		render.JSON(w, r, TranslationsResponse{
+			Translations: translations,
+			Items:        len(translations),
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package translationsave
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"song-library/internal/translation"
+	"strings"
+)
+
+type TranslationSaver interface {
+	TranslationSave(ctx context.Context, groupName string, songName string, lang string, text string) (translationId int, err error)
+}
+
+func New(log *slog.Logger, translationSaver TranslationSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.translations.save"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req models.SongTranslation
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.ErrorContext(r.Context(), "Filed to decode request body", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "Request body decoded",
+			slog.String("group", req.GroupName),
+			slog.String("song", req.SongName),
+			slog.String("lang", req.Lang))
+
+		if req.GroupName == "" || req.SongName == "" || req.Lang == "" || strings.TrimSpace(req.Text) == "" {
+			log.InfoContext(r.Context(), "Cannot save translation, group, song name, language or text is missing")
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		lang, err := translation.Canonical(req.Lang)
+		if err != nil {
+			log.InfoContext(r.Context(), "Cannot save translation, language tag is incorrect",
+				slog.String("lang", req.Lang),
+				slog.Any("error", err))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		translationId, err := translationSaver.TranslationSave(r.Context(), req.GroupName, req.SongName, lang, req.Text)
+		if err != nil {
+			if errors.Is(err, storage.ErrSongNotFound) {
+				log.InfoContext(r.Context(), "Song not found",
+					slog.String("group", req.GroupName),
+					slog.String("song", req.SongName))
+
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			if errors.Is(err, storage.ErrTranslationExists) {
+				log.InfoContext(r.Context(), "Translation already exists",
+					slog.String("group", req.GroupName),
+					slog.String("song", req.SongName),
+					slog.String("lang", lang))
+
+				w.WriteHeader(http.StatusAlreadyReported)
+
+				return
+			}
+
+			log.ErrorContext(r.Context(), "Failed to save translation", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "Translation successfully saved",
+			slog.String("group", req.GroupName),
+			slog.String("song", req.SongName),
+			slog.String("lang", lang),
+			slog.Int("translation_id", translationId),
+		)
+
+		w.WriteHeader(http.StatusCreated)
+	}
+}
@@ -0,0 +1,92 @@
+package translationupdate
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"song-library/internal/translation"
+	"strings"
+)
+
+type TranslationUpdater interface {
+	TranslationUpdate(ctx context.Context, groupName string, songName string, lang string, text string) error
+}
+
+func New(log *slog.Logger, translationUpdater TranslationUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.translations.update"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req models.SongTranslation
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.ErrorContext(r.Context(), "Filed to decode request body", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "Request body decoded",
+			slog.String("group", req.GroupName),
+			slog.String("song", req.SongName),
+			slog.String("lang", req.Lang))
+
+		if req.GroupName == "" || req.SongName == "" || req.Lang == "" || strings.TrimSpace(req.Text) == "" {
+			log.InfoContext(r.Context(), "Cannot update translation, group, song name, language or text is missing")
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		lang, err := translation.Canonical(req.Lang)
+		if err != nil {
+			log.InfoContext(r.Context(), "Cannot update translation, language tag is incorrect",
+				slog.String("lang", req.Lang),
+				slog.Any("error", err))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		err = translationUpdater.TranslationUpdate(r.Context(), req.GroupName, req.SongName, lang, req.Text)
+		if err != nil {
+			if errors.Is(err, storage.ErrTranslationNotFound) {
+				log.InfoContext(r.Context(), "Translation not found",
+					slog.String("group", req.GroupName),
+					slog.String("song", req.SongName),
+					slog.String("lang", lang))
+
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			log.ErrorContext(r.Context(), "Failed to update translation", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "Translation successfully updated",
+			slog.String("group", req.GroupName),
+			slog.String("song", req.SongName),
+			slog.String("lang", lang),
+		)
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
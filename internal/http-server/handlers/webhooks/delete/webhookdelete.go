@@ -0,0 +1,60 @@
+package webhookdelete
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"log/slog"
+	"net/http"
+	"song-library/internal/storage"
+	"strconv"
+)
+
+type WebhookDeleter interface {
+	WebhookDelete(ctx context.Context, webhookID int) error
+}
+
+// New returns the handler of DELETE /webhooks/{id}, the delivery log of the webhook is deleted too.
+func New(log *slog.Logger, webhookDeleter WebhookDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhooks.delete"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id := chi.URLParam(r, "id")
+
+		webhookID, err := strconv.Atoi(id)
+		if err != nil || webhookID < 1 {
+			log.InfoContext(r.Context(), "Bad request: webhook id is incorrect", slog.String("id", id))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		err = webhookDeleter.WebhookDelete(r.Context(), webhookID)
+		if err != nil {
+			if errors.Is(err, storage.ErrWebhookNotFound) {
+				log.InfoContext(r.Context(), "Webhook not found", slog.Int("webhook_id", webhookID))
+
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			log.ErrorContext(r.Context(), "Failed to delete webhook", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "Webhook successfully deleted", slog.Int("webhook_id", webhookID))
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "song-library/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeliveriesGetter is an autogenerated mock type for the DeliveriesGetter type
+type DeliveriesGetter struct {
+	mock.Mock
+}
+
+// WebhookDeliveries provides a mock function with given fields: ctx, webhookID, limit
+func (_m *DeliveriesGetter) WebhookDeliveries(ctx context.Context, webhookID int, limit int) ([]models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveries")
+	}
+
+	var r0 []models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.WebhookDelivery, error)); ok {
+		return rf(ctx, webhookID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDeliveriesGetter creates a new instance of DeliveriesGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeliveriesGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeliveriesGetter {
+	mock := &DeliveriesGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
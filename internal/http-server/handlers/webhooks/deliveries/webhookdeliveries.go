@@ -0,0 +1,89 @@
+package webhookdeliveries
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"strconv"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+type DeliveriesResponse struct {
+	Deliveries []models.WebhookDelivery `json:"deliveries"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=DeliveriesGetter
+type DeliveriesGetter interface {
+	WebhookDeliveries(ctx context.Context, webhookID int, limit int) ([]models.WebhookDelivery, error)
+}
+
+// New returns the handler of GET /webhooks/{id}/deliveries, the delivery log
+// of the webhook, the newest deliveries first.
+func New(log *slog.Logger, deliveriesGetter DeliveriesGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhooks.deliveries"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id := chi.URLParam(r, "id")
+		limit := r.URL.Query().Get("limit")
+
+		webhookID, err := strconv.Atoi(id)
+		if err != nil || webhookID < 1 {
+			log.InfoContext(r.Context(), "Bad request: webhook id is incorrect", slog.String("id", id))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		intLimit := defaultLimit
+		if limit != "" {
+			intLimit, err = strconv.Atoi(limit)
+			if err != nil || intLimit < 1 || intLimit > maxLimit {
+				log.InfoContext(r.Context(), "Bad request: get parameter 'limit' is incorrect",
+					slog.String("limit", limit))
+
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		deliveries, err := deliveriesGetter.WebhookDeliveries(r.Context(), webhookID, intLimit)
+		if err != nil {
+			if errors.Is(err, storage.ErrWebhookNotFound) {
+				log.InfoContext(r.Context(), "Webhook not found", slog.Int("webhook_id", webhookID))
+
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			log.ErrorContext(r.Context(), "Failed to get webhook deliveries", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		if deliveries == nil {
+			deliveries = []models.WebhookDelivery{}
+		}
+
+		render.JSON(w, r, DeliveriesResponse{Deliveries: deliveries})
+	}
+}
@@ -0,0 +1,115 @@
+package webhookdeliveries
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/http-server/handlers/webhooks/deliveries/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"testing"
+)
+
+func TestWebhookDeliveriesHandler(t *testing.T) {
+	deliveries := []models.WebhookDelivery{
+		{
+			ID:           2,
+			WebhookID:    1,
+			Event:        models.EventSongDeleted,
+			Payload:      json.RawMessage(`{"event":"song.deleted"}`),
+			Status:       models.DeliveryPending,
+			Attempts:     1,
+			ResponseCode: http.StatusBadGateway,
+			LastError:    "webhook responded with status 502",
+		},
+		{
+			ID:           1,
+			WebhookID:    1,
+			Event:        models.EventSongCreated,
+			Payload:      json.RawMessage(`{"event":"song.created"}`),
+			Status:       models.DeliveryDelivered,
+			Attempts:     1,
+			ResponseCode: http.StatusOK,
+		},
+	}
+
+	cases := []struct {
+		name       string
+		url        string
+		limit      int
+		mockError  error
+		httpStatus int
+	}{
+		{
+			name:       "Success",
+			url:        "/webhooks/1/deliveries",
+			limit:      defaultLimit,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "Limit",
+			url:        "/webhooks/1/deliveries?limit=2",
+			limit:      2,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "Incorrect limit",
+			url:        "/webhooks/1/deliveries?limit=1000",
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Incorrect id",
+			url:        "/webhooks/first/deliveries",
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Webhook not found",
+			url:        "/webhooks/1/deliveries",
+			limit:      defaultLimit,
+			mockError:  storage.ErrWebhookNotFound,
+			httpStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Storage error",
+			url:        "/webhooks/1/deliveries",
+			limit:      defaultLimit,
+			mockError:  errors.New("internal error"),
+			httpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deliveriesGetterMock := mocks.NewDeliveriesGetter(t)
+
+			if tc.limit != 0 {
+				deliveriesGetterMock.On("WebhookDeliveries", mock.Anything, 1, tc.limit).
+					Return(deliveries, tc.mockError).Once()
+			}
+
+			router := chi.NewRouter()
+			router.Get("/webhooks/{id}/deliveries", New(slogdiscard.NewDiscardLogger(), deliveriesGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.httpStatus, rr.Code)
+
+			if tc.httpStatus == http.StatusOK {
+				var resp DeliveriesResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, deliveries, resp.Deliveries)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package webhooksget
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"song-library/internal/models"
+)
+
+type WebhooksResponse struct {
+	Webhooks []models.Webhook `json:"webhooks"`
+}
+
+type WebhooksGetter interface {
+	Webhooks(ctx context.Context) ([]models.Webhook, error)
+}
+
+// New returns the handler of GET /webhooks, the secrets are not sent.
+func New(log *slog.Logger, webhooksGetter WebhooksGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhooks.get"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		webhooks, err := webhooksGetter.Webhooks(r.Context())
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to get webhooks", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		if webhooks == nil {
+			webhooks = []models.Webhook{}
+		}
+
+		render.JSON(w, r, WebhooksResponse{Webhooks: webhooks})
+	}
+}
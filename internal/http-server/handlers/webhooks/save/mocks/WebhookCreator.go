@@ -0,0 +1,57 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "song-library/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WebhookCreator is an autogenerated mock type for the WebhookCreator type
+type WebhookCreator struct {
+	mock.Mock
+}
+
+// WebhookCreate provides a mock function with given fields: ctx, webhook
+func (_m *WebhookCreator) WebhookCreate(ctx context.Context, webhook models.Webhook) (models.Webhook, error) {
+	ret := _m.Called(ctx, webhook)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookCreate")
+	}
+
+	var r0 models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhook) (models.Webhook, error)); ok {
+		return rf(ctx, webhook)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Webhook) models.Webhook); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Get(0).(models.Webhook)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Webhook) error); ok {
+		r1 = rf(ctx, webhook)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWebhookCreator creates a new instance of WebhookCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookCreator {
+	mock := &WebhookCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
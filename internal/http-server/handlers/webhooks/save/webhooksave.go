@@ -0,0 +1,109 @@
+package webhooksave
+
+import (
+	"context"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"song-library/internal/models"
+)
+
+// minSecretLen is the minimal length of the webhook secret in bytes
+const minSecretLen = 16
+
+type SaveRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret signs the payloads with HMAC-SHA256
+	Secret string `json:"secret"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=WebhookCreator
+type WebhookCreator interface {
+	WebhookCreate(ctx context.Context, webhook models.Webhook) (models.Webhook, error)
+}
+
+// New returns the handler of POST /webhooks, the subscription of a URL to song lifecycle events.
+func New(log *slog.Logger, webhookCreator WebhookCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhooks.save"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req SaveRequest
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.ErrorContext(r.Context(), "Filed to decode request body", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "Request body decoded",
+			slog.String("url", req.URL),
+			slog.Any("events", req.Events))
+
+		webhookURL, err := url.Parse(req.URL)
+		if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+			log.InfoContext(r.Context(), "Cannot save webhook, URL is incorrect", slog.String("url", req.URL))
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if len(req.Events) == 0 {
+			log.InfoContext(r.Context(), "Cannot save webhook, events are missing")
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		for _, event := range req.Events {
+			if !slices.Contains(models.Events, event) {
+				log.InfoContext(r.Context(), "Cannot save webhook, event is unknown", slog.String("event", event))
+
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		if len(req.Secret) < minSecretLen {
+			log.InfoContext(r.Context(), "Cannot save webhook, secret is too short")
+
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		slices.Sort(req.Events)
+
+		webhook, err := webhookCreator.WebhookCreate(r.Context(), models.Webhook{
+			URL:    req.URL,
+			Events: slices.Compact(req.Events),
+			Secret: req.Secret,
+		})
+		if err != nil {
+			log.ErrorContext(r.Context(), "Failed to save webhook", slog.Any("error", err))
+
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		log.InfoContext(r.Context(), "Webhook successfully saved", slog.Int("webhook_id", webhook.ID))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, webhook)
+	}
+}
@@ -0,0 +1,105 @@
+package webhooksave
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/http-server/handlers/webhooks/save/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"testing"
+)
+
+func TestWebhookSaveHandler(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	cases := []struct {
+		name       string
+		request    SaveRequest
+		events     []string
+		mockError  error
+		httpStatus int
+	}{
+		{
+			name:       "Success",
+			request:    SaveRequest{URL: "https://search.example.com/hooks", Events: []string{"song.updated", "song.created", "song.updated"}, Secret: secret},
+			events:     []string{"song.created", "song.updated"},
+			httpStatus: http.StatusCreated,
+		},
+		{
+			name:       "Incorrect URL",
+			request:    SaveRequest{URL: "search.example.com/hooks", Events: []string{"song.created"}, Secret: secret},
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Not a web URL",
+			request:    SaveRequest{URL: "ftp://search.example.com/hooks", Events: []string{"song.created"}, Secret: secret},
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Empty events",
+			request:    SaveRequest{URL: "https://search.example.com/hooks", Secret: secret},
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Unknown event",
+			request:    SaveRequest{URL: "https://search.example.com/hooks", Events: []string{"song.played"}, Secret: secret},
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Short secret",
+			request:    SaveRequest{URL: "https://search.example.com/hooks", Events: []string{"song.created"}, Secret: "secret"},
+			httpStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Storage error",
+			request:    SaveRequest{URL: "https://search.example.com/hooks", Events: []string{"song.deleted"}, Secret: secret},
+			events:     []string{"song.deleted"},
+			mockError:  errors.New("internal error"),
+			httpStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			webhookCreatorMock := mocks.NewWebhookCreator(t)
+
+			if tc.events != nil {
+				webhook := models.Webhook{URL: tc.request.URL, Events: tc.events, Secret: secret}
+				created := webhook
+				created.ID = 1
+
+				webhookCreatorMock.On("WebhookCreate", mock.Anything, webhook).
+					Return(created, tc.mockError).Once()
+			}
+
+			handler := New(slogdiscard.NewDiscardLogger(), webhookCreatorMock)
+
+			var buf bytes.Buffer
+			require.NoError(t, json.NewEncoder(&buf).Encode(tc.request))
+
+			req, err := http.NewRequest(http.MethodPost, "/webhooks", &buf)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.httpStatus, rr.Code)
+
+			if tc.httpStatus == http.StatusCreated {
+				require.NotContains(t, rr.Body.String(), secret)
+
+				var resp models.Webhook
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, 1, resp.ID)
+				require.Equal(t, tc.events, resp.Events)
+			}
+		})
+	}
+}
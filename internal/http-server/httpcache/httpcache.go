@@ -0,0 +1,59 @@
+package httpcache
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CacheControl returns middleware that sets the Cache-Control header of GET and HEAD responses.
+// Responses are cached by shared caches only if public is true, with zero maxAge
+// clients revalidate every request with If-Modified-Since.
+func CacheControl(maxAge time.Duration, public bool) func(next http.Handler) http.Handler {
+	visibility := "private"
+	if public {
+		visibility = "public"
+	}
+
+	value := visibility + ", no-cache"
+	if maxAge > 0 {
+		value = fmt.Sprintf("%s, max-age=%d", visibility, int(maxAge.Seconds()))
+	}
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				w.Header().Set("Cache-Control", value)
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// NotModified sets the Last-Modified header and reports whether the copy of the client
+// is still fresh by the If-Modified-Since header, then the handler responds 304 Not Modified.
+// A zero lastModified is unknown, the response is always sent.
+func NotModified(w http.ResponseWriter, r *http.Request, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+
+	// HTTP dates have a precision of one second
+	lastModified = lastModified.UTC().Truncate(time.Second)
+
+	w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	return !lastModified.After(ifModifiedSince)
+}
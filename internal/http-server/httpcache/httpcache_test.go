@@ -0,0 +1,83 @@
+package httpcache
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 5, 1, 12, 30, 15, 500_000_000, time.UTC)
+
+	cases := []struct {
+		name            string
+		method          string
+		ifModifiedSince string
+		lastModified    time.Time
+		notModified     bool
+	}{
+		{name: "No If-Modified-Since", lastModified: lastModified},
+		{name: "Not modified", ifModifiedSince: "Wed, 01 May 2024 12:30:15 GMT", lastModified: lastModified, notModified: true},
+		{name: "Later If-Modified-Since", ifModifiedSince: "Thu, 02 May 2024 00:00:00 GMT", lastModified: lastModified, notModified: true},
+		{name: "Modified", ifModifiedSince: "Wed, 01 May 2024 12:30:14 GMT", lastModified: lastModified},
+		{name: "Invalid If-Modified-Since", ifModifiedSince: "yesterday", lastModified: lastModified},
+		{name: "Unknown last modified", ifModifiedSince: "Wed, 01 May 2024 12:30:15 GMT"},
+		{name: "Not GET", method: http.MethodPut, ifModifiedSince: "Wed, 01 May 2024 12:30:15 GMT", lastModified: lastModified},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := tc.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req, err := http.NewRequest(method, "/info", nil)
+			require.NoError(t, err)
+			if tc.ifModifiedSince != "" {
+				req.Header.Set("If-Modified-Since", tc.ifModifiedSince)
+			}
+
+			rr := httptest.NewRecorder()
+
+			require.Equal(t, tc.notModified, NotModified(rr, req, tc.lastModified))
+
+			if !tc.lastModified.IsZero() {
+				require.Equal(t, "Wed, 01 May 2024 12:30:15 GMT", rr.Header().Get("Last-Modified"))
+			} else {
+				require.Empty(t, rr.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
+
+func TestCacheControl(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		maxAge       time.Duration
+		public       bool
+		cacheControl string
+	}{
+		{name: "Revalidate", method: http.MethodGet, public: true, cacheControl: "public, no-cache"},
+		{name: "Max age", method: http.MethodGet, maxAge: time.Minute, public: true, cacheControl: "public, max-age=60"},
+		{name: "Private", method: http.MethodGet, maxAge: time.Minute, cacheControl: "private, max-age=60"},
+		{name: "Not GET", method: http.MethodPost, maxAge: time.Minute, public: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+			req, err := http.NewRequest(tc.method, "/songs", nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			CacheControl(tc.maxAge, tc.public)(next).ServeHTTP(rr, req)
+
+			require.Equal(t, tc.cacheControl, rr.Header().Get("Cache-Control"))
+		})
+	}
+}
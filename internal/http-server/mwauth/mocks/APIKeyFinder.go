@@ -0,0 +1,57 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "song-library/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyFinder is an autogenerated mock type for the APIKeyFinder type
+type APIKeyFinder struct {
+	mock.Mock
+}
+
+// APIKeyFind provides a mock function with given fields: ctx, keyHash
+func (_m *APIKeyFinder) APIKeyFind(ctx context.Context, keyHash string) (models.APIKey, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for APIKeyFind")
+	}
+
+	var r0 models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.APIKey, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.APIKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		r0 = ret.Get(0).(models.APIKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAPIKeyFinder creates a new instance of APIKeyFinder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyFinder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyFinder {
+	mock := &APIKeyFinder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,148 @@
+package mwauth
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/chi/v5/middleware"
+	"log/slog"
+	"net/http"
+	"song-library/internal/apikey"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/mwlogger"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"strings"
+)
+
+// HeaderAPIKey is the request header with the API key.
+const HeaderAPIKey = "X-API-Key"
+
+type ctxKey struct{}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=APIKeyFinder
+type APIKeyFinder interface {
+	APIKeyFind(ctx context.Context, keyHash string) (models.APIKey, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=TokenVerifier
+type TokenVerifier interface {
+	Verify(token string) (auth.Principal, error)
+}
+
+// New returns middleware that authenticates the request by API key in the X-API-Key header
+// or by JWT in the "Authorization: Bearer" header. API keys have the admin role.
+// Requests without credentials pass as anonymous, requests with invalid credentials get 401 Unauthorized.
+// Permissions are checked by Require.
+func New(log *slog.Logger, apiKeyFinder APIKeyFinder, tokenVerifier TokenVerifier) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		const op = "middleware.auth"
+
+		log := log.With(slog.String("op", op))
+
+		log.Info("Auth middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			log := log.With(slog.String("request_id", middleware.GetReqID(r.Context())))
+
+			var principal auth.Principal
+
+			if key := r.Header.Get(HeaderAPIKey); key != "" {
+				apiKey, err := apiKeyFinder.APIKeyFind(r.Context(), apikey.Hash(key))
+				if err != nil {
+					if errors.Is(err, storage.ErrAPIKeyNotFound) {
+						log.InfoContext(r.Context(), "Unauthorized: API key is invalid or revoked")
+
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+
+					log.ErrorContext(r.Context(), "Failed to find API key", slog.Any("error", err))
+
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				principal = auth.APIKeyPrincipal(apiKey.ID, apiKey.Name)
+
+			} else if token, ok := bearerToken(r); ok {
+				var err error
+
+				principal, err = tokenVerifier.Verify(token)
+				if err != nil {
+					log.InfoContext(r.Context(), "Unauthorized: bearer token is invalid", slog.Any("error", err))
+
+					w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+			} else {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mwlogger.SetSubject(r.Context(), principal.Subject)
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// Require returns middleware that allows only requests authenticated by New
+// with the role or a higher one. Anonymous requests get 401 Unauthorized,
+// requests without the role get 403 Forbidden.
+func Require(log *slog.Logger, role auth.Role) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		const op = "middleware.auth.require"
+
+		log := log.With(slog.String("op", op), slog.String("role", string(role)))
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFrom(r.Context())
+			if !ok {
+				log.InfoContext(r.Context(), "Unauthorized: credentials are missing",
+					slog.String("request_id", middleware.GetReqID(r.Context())))
+
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			if !principal.Has(role) {
+				log.InfoContext(r.Context(), "Forbidden: role is not allowed",
+					slog.String("request_id", middleware.GetReqID(r.Context())),
+					slog.String("subject", principal.Subject),
+					slog.String("subject_role", string(principal.Role)))
+
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// WithPrincipal returns the context of the request authenticated as the principal.
+func WithPrincipal(ctx context.Context, principal auth.Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, principal)
+}
+
+// PrincipalFrom returns the client the request was authenticated as.
+func PrincipalFrom(ctx context.Context) (auth.Principal, bool) {
+	principal, ok := ctx.Value(ctxKey{}).(auth.Principal)
+	return principal, ok
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
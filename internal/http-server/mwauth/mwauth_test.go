@@ -0,0 +1,155 @@
+package mwauth
+
+import (
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/apikey"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/mwauth/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"testing"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	cases := []struct {
+		name          string
+		key           string
+		token         string
+		mockKey       models.APIKey
+		mockKeyError  error
+		mockPrincipal auth.Principal
+		mockJWTError  error
+		role          auth.Role
+		httpStatus    int
+	}{
+		{
+			name:       "API key",
+			key:        "sl_valid",
+			mockKey:    models.APIKey{ID: 1, Name: "importer"},
+			role:       auth.RoleAdmin,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "Missing credentials",
+			role:       auth.RoleEditor,
+			httpStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "Unknown or revoked key",
+			key:          "sl_revoked",
+			mockKeyError: storage.ErrAPIKeyNotFound,
+			role:         auth.RoleEditor,
+			httpStatus:   http.StatusUnauthorized,
+		},
+		{
+			name:         "Storage error",
+			key:          "sl_valid",
+			mockKeyError: errors.New("internal error"),
+			role:         auth.RoleEditor,
+			httpStatus:   http.StatusInternalServerError,
+		},
+		{
+			name:          "JWT editor",
+			token:         "editor.jwt",
+			mockPrincipal: auth.Principal{Subject: "alice", Role: auth.RoleEditor},
+			role:          auth.RoleEditor,
+			httpStatus:    http.StatusOK,
+		},
+		{
+			name:          "JWT admin has editor permissions",
+			token:         "admin.jwt",
+			mockPrincipal: auth.Principal{Subject: "bob", Role: auth.RoleAdmin},
+			role:          auth.RoleEditor,
+			httpStatus:    http.StatusOK,
+		},
+		{
+			name:          "JWT reader cannot edit",
+			token:         "reader.jwt",
+			mockPrincipal: auth.Principal{Subject: "carol", Role: auth.RoleReader},
+			role:          auth.RoleEditor,
+			httpStatus:    http.StatusForbidden,
+		},
+		{
+			name:          "JWT without roles",
+			token:         "norole.jwt",
+			mockPrincipal: auth.Principal{Subject: "dave"},
+			role:          auth.RoleReader,
+			httpStatus:    http.StatusForbidden,
+		},
+		{
+			name:         "Invalid JWT",
+			token:        "expired.jwt",
+			mockJWTError: errors.New("token is expired"),
+			role:         auth.RoleReader,
+			httpStatus:   http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			apiKeyFinderMock := mocks.NewAPIKeyFinder(t)
+			apiKeyFinderMock.On("APIKeyFind", mock.Anything, apikey.Hash(tc.key)).
+				Return(tc.mockKey, tc.mockKeyError).Maybe()
+
+			tokenVerifierMock := mocks.NewTokenVerifier(t)
+			tokenVerifierMock.On("Verify", tc.token).
+				Return(tc.mockPrincipal, tc.mockJWTError).Maybe()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, ok := PrincipalFrom(r.Context())
+				require.True(t, ok)
+
+				w.WriteHeader(http.StatusOK)
+			})
+
+			log := slogdiscard.NewDiscardLogger()
+			handler := New(log, apiKeyFinderMock, tokenVerifierMock)(Require(log, tc.role)(next))
+
+			req, err := http.NewRequest(http.MethodPost, "/songs", nil)
+			require.NoError(t, err)
+
+			if tc.key != "" {
+				req.Header.Set(HeaderAPIKey, tc.key)
+			}
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.httpStatus, rr.Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_Anonymous(t *testing.T) {
+	hasPrincipal := true
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasPrincipal = PrincipalFrom(r.Context())
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := New(slogdiscard.NewDiscardLogger(),
+		mocks.NewAPIKeyFinder(t), mocks.NewTokenVerifier(t))(next)
+
+	req, err := http.NewRequest(http.MethodGet, "/songs", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.False(t, hasPrincipal)
+}
@@ -0,0 +1,287 @@
+package mwcompress
+
+import (
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Supported encodings in the order of preference if the client accepts several with the same quality.
+const (
+	encodingZstd   = "zstd"
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+var preference = []string{encodingZstd, encodingBrotli, encodingGzip}
+
+// compressibleTypes are the content types worth compressing, event streams are not compressed
+// because every event must reach the client as soon as it is flushed.
+var compressibleTypes = []string{
+	"text/plain",
+	"text/html",
+	"text/csv",
+	"application/json",
+	"application/problem+json",
+	"application/xml",
+	"application/yaml",
+	"application/javascript",
+}
+
+type encoder interface {
+	io.Writer
+	Flush() error
+	Close() error
+	Reset(w io.Writer)
+}
+
+var encoders = map[string]*sync.Pool{
+	encodingZstd: {New: func() any {
+		// Errors are returned only for invalid options
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return enc
+	}},
+	encodingBrotli: {New: func() any {
+		return brotli.NewWriterLevel(nil, 5)
+	}},
+	encodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+}
+
+// New returns middleware that compresses responses with zstd, brotli or gzip
+// negotiated by the Accept-Encoding header. Responses smaller than minSize bytes,
+// responses that are already encoded and not compressible content types are sent as is.
+func New(minSize int) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiate(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{
+				ResponseWriter: w,
+				encoding:       encoding,
+				minSize:        minSize,
+			}
+
+			// Not deferred: if the handler panics, the buffered response is dropped
+			// and the recoverer writes its own response
+			next.ServeHTTP(cw, r)
+			cw.close()
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// negotiate returns the supported encoding with the highest quality in the Accept-Encoding header
+// or an empty string if none of them is acceptable.
+func negotiate(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	wildcard := -1.0
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		if name == "*" {
+			wildcard = quality
+		} else {
+			qualities[name] = quality
+		}
+	}
+
+	best, bestQuality := "", 0.0
+
+	for _, encoding := range preference {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality = wildcard
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = encoding, quality
+		}
+	}
+
+	return best
+}
+
+// compressWriter buffers the beginning of the response until it is known
+// whether the response is large enough to be compressed.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status      int
+	wroteHeader bool
+	decided     bool
+	buf         []byte
+	enc         encoder
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+
+	cw.status = status
+	cw.wroteHeader = true
+
+	// Informational responses are sent at once and do not end the response
+	if status >= 100 && status < 200 {
+		cw.wroteHeader = false
+		cw.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+
+	if cw.decided {
+		if cw.enc != nil {
+			return cw.enc.Write(p)
+		}
+
+		return cw.ResponseWriter.Write(p)
+	}
+
+	if !cw.compressible() {
+		if err := cw.decide(false); err != nil {
+			return 0, err
+		}
+
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) < cw.minSize {
+		return len(p), nil
+	}
+
+	if err := cw.decide(true); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Flush sends the buffered response, a response flushed before reaching
+// the minimum size is not compressed.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			cw.WriteHeader(http.StatusOK)
+		}
+
+		_ = cw.decide(false)
+	}
+
+	if cw.enc != nil {
+		_ = cw.enc.Flush()
+	}
+
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+func (cw *compressWriter) compressible() bool {
+	if cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType, _, _ := strings.Cut(header.Get("Content-Type"), ";")
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+
+	for _, compressibleType := range compressibleTypes {
+		if contentType == compressibleType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decide writes the header and the buffered part of the response, compressed or not.
+func (cw *compressWriter) decide(compress bool) error {
+	cw.decided = true
+
+	if compress {
+		cw.Header().Set("Content-Encoding", cw.encoding)
+		cw.Header().Del("Content-Length")
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	buf := cw.buf
+	cw.buf = nil
+
+	if !compress {
+		if len(buf) > 0 {
+			_, err := cw.ResponseWriter.Write(buf)
+			return err
+		}
+
+		return nil
+	}
+
+	cw.enc = encoders[cw.encoding].Get().(encoder)
+	cw.enc.Reset(cw.ResponseWriter)
+
+	_, err := cw.enc.Write(buf)
+
+	return err
+}
+
+// close sends the response if it is still buffered and finishes the compressed stream.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			return
+		}
+
+		_ = cw.decide(false)
+	}
+
+	if cw.enc != nil {
+		_ = cw.enc.Close()
+		cw.enc.Reset(io.Discard)
+		encoders[cw.encoding].Put(cw.enc)
+		cw.enc = nil
+	}
+}
@@ -0,0 +1,151 @@
+package mwcompress
+
+import (
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		acceptEncoding string
+		encoding       string
+	}{
+		{acceptEncoding: "", encoding: ""},
+		{acceptEncoding: "identity", encoding: ""},
+		{acceptEncoding: "gzip", encoding: encodingGzip},
+		{acceptEncoding: "gzip, deflate, br", encoding: encodingBrotli},
+		{acceptEncoding: "gzip, deflate, br, zstd", encoding: encodingZstd},
+		{acceptEncoding: "br;q=0.5, gzip;q=0.8", encoding: encodingGzip},
+		{acceptEncoding: "zstd;q=0, br;q=0", encoding: ""},
+		{acceptEncoding: "*", encoding: encodingZstd},
+		{acceptEncoding: "*;q=0.1, gzip", encoding: encodingGzip},
+		{acceptEncoding: "GZIP", encoding: encodingGzip},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.acceptEncoding, func(t *testing.T) {
+			require.Equal(t, tc.encoding, negotiate(tc.acceptEncoding))
+		})
+	}
+}
+
+func TestCompressMiddleware(t *testing.T) {
+	const minSize = 100
+
+	large := strings.Repeat("Yesterday, all my troubles seemed so far away\n", 20)
+
+	cases := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		status         int
+		body           string
+		encoding       string
+	}{
+		{name: "Gzip", acceptEncoding: "gzip", contentType: "application/json", body: large, encoding: encodingGzip},
+		{name: "Brotli", acceptEncoding: "br", contentType: "application/json", body: large, encoding: encodingBrotli},
+		{name: "Zstd", acceptEncoding: "zstd", contentType: "text/plain; charset=utf-8", body: large, encoding: encodingZstd},
+		{name: "Small body", acceptEncoding: "gzip", contentType: "application/json", body: "{}"},
+		{name: "Not accepted", contentType: "application/json", body: large},
+		{name: "Not compressible", acceptEncoding: "gzip", contentType: "image/png", body: large},
+		{name: "Event stream", acceptEncoding: "gzip", contentType: "text/event-stream", body: large},
+		{name: "Status code is kept", acceptEncoding: "gzip", contentType: "application/json", status: http.StatusCreated, body: large, encoding: encodingGzip},
+		{name: "No content", acceptEncoding: "gzip", status: http.StatusNoContent},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.contentType != "" {
+					w.Header().Set("Content-Type", tc.contentType)
+				}
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+
+				// Written in parts to check buffering
+				for _, line := range strings.SplitAfter(tc.body, "\n") {
+					_, _ = io.WriteString(w, line)
+				}
+			})
+
+			req, err := http.NewRequest(http.MethodGet, "/songs", nil)
+			require.NoError(t, err)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+
+			rr := httptest.NewRecorder()
+			New(minSize)(next).ServeHTTP(rr, req)
+
+			status := tc.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			require.Equal(t, status, rr.Code)
+			require.Equal(t, tc.encoding, rr.Header().Get("Content-Encoding"))
+			require.Contains(t, rr.Header().Values("Vary"), "Accept-Encoding")
+			require.Equal(t, tc.body, decode(t, tc.encoding, rr.Body))
+
+			if tc.encoding != "" {
+				require.Less(t, rr.Body.Len(), len(tc.body))
+			}
+		})
+	}
+}
+
+func TestCompressMiddlewareFlush(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "first")
+		w.(http.Flusher).Flush()
+		_, _ = io.WriteString(w, strings.Repeat(" second", 100))
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "/songs", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rr := httptest.NewRecorder()
+	New(100)(next).ServeHTTP(rr, req)
+
+	// The response is sent as is once it was flushed before reaching the minimum size
+	require.True(t, rr.Flushed)
+	require.Empty(t, rr.Header().Get("Content-Encoding"))
+	require.Equal(t, "first"+strings.Repeat(" second", 100), rr.Body.String())
+}
+
+func decode(t *testing.T, encoding string, body io.Reader) string {
+	t.Helper()
+
+	var reader io.Reader
+
+	switch encoding {
+	case encodingGzip:
+		gzipReader, err := gzip.NewReader(body)
+		require.NoError(t, err)
+		reader = gzipReader
+	case encodingBrotli:
+		reader = brotli.NewReader(body)
+	case encodingZstd:
+		zstdReader, err := zstd.NewReader(body)
+		require.NoError(t, err)
+		defer zstdReader.Close()
+		reader = zstdReader
+	default:
+		reader = body
+	}
+
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+
+	return string(decoded)
+}
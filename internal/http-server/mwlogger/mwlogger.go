@@ -1,12 +1,21 @@
 package mwlogger
 
 import (
+	"context"
 	"github.com/go-chi/chi/v5/middleware"
 	"log/slog"
 	"net/http"
 	"time"
 )
 
+type ctxKey struct{}
+
+// requestFields are filled by the next middlewares and handlers
+// and logged when the request is completed.
+type requestFields struct {
+	subject string
+}
+
 func New(log *slog.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 
@@ -20,20 +29,48 @@ func New(log *slog.Logger) func(next http.Handler) http.Handler {
 				slog.String("user_agent", r.UserAgent()),
 				slog.String("request_id", middleware.GetReqID(r.Context())),
 			)
-			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww := WrapResponseWriter(w, r)
+
+			fields := &requestFields{}
 
 			t1 := time.Now()
 			defer func() {
-				entry.Info("Request completed",
+				attrs := []any{
 					slog.Int("status", ww.Status()),
 					slog.Int("bytes", ww.BytesWritten()),
 					slog.String("duration", time.Since(t1).String()),
-				)
+				}
+				if fields.subject != "" {
+					attrs = append(attrs, slog.String("subject", fields.subject))
+				}
+
+				entry.InfoContext(r.Context(), "Request completed", attrs...)
 			}()
 
-			next.ServeHTTP(ww, r)
+			ctx := context.WithValue(r.Context(), ctxKey{}, fields)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
 		}
 
 		return http.HandlerFunc(fn)
 	}
 }
+
+// SetSubject records the authenticated subject of the request
+// in the "Request completed" log record.
+func SetSubject(ctx context.Context, subject string) {
+	if fields, ok := ctx.Value(ctxKey{}).(*requestFields); ok {
+		fields.subject = subject
+	}
+}
+
+// WrapResponseWriter returns the writer already wrapped by a previous middleware,
+// so the status and the size of the response are tracked by one wrapper.
+// Other writers are wrapped.
+func WrapResponseWriter(w http.ResponseWriter, r *http.Request) middleware.WrapResponseWriter {
+	if ww, ok := w.(middleware.WrapResponseWriter); ok {
+		return ww
+	}
+
+	return middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+}
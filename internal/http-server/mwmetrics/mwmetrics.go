@@ -0,0 +1,62 @@
+package mwmetrics
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"net/http"
+	"song-library/internal/http-server/mwlogger"
+	"strconv"
+	"time"
+)
+
+// notFoundRoute is the route label of requests that matched no route,
+// the path is not used as a label to keep the number of series bounded.
+const notFoundRoute = "not_found"
+
+// New returns middleware that counts requests and observes their latency
+// by method, route pattern and status code.
+func New(registerer prometheus.Registerer) func(next http.Handler) http.Handler {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "song_library",
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "song_library",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by method and route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	registerer.MustRegister(requests, duration)
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := mwlogger.WrapResponseWriter(w, r)
+
+			t1 := time.Now()
+			defer func() {
+				route := notFoundRoute
+				if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+					route = routeCtx.RoutePattern()
+				}
+
+				status := ww.Status()
+				if status == 0 {
+					// Nothing was written, net/http responds 200
+					status = http.StatusOK
+				}
+
+				requests.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+				duration.WithLabelValues(r.Method, route).Observe(time.Since(t1).Seconds())
+			}()
+
+			next.ServeHTTP(ww, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,54 @@
+package mwmetrics
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	router := chi.NewRouter()
+	router.Use(New(registry))
+	router.Get("/songs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	router.Delete("/songs", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("deleted"))
+	})
+
+	for _, req := range []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/songs"},
+		{http.MethodGet, "/songs"},
+		{http.MethodDelete, "/songs"},
+		{http.MethodGet, "/unknown/path"},
+	} {
+		r, err := http.NewRequest(req.method, req.path, nil)
+		require.NoError(t, err)
+
+		router.ServeHTTP(httptest.NewRecorder(), r)
+	}
+
+	expected := `
+# HELP song_library_http_requests_total Number of HTTP requests by method, route and status code.
+# TYPE song_library_http_requests_total counter
+song_library_http_requests_total{method="DELETE",route="/songs",status="200"} 1
+song_library_http_requests_total{method="GET",route="/songs",status="204"} 2
+song_library_http_requests_total{method="GET",route="not_found",status="404"} 1
+`
+	err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "song_library_http_requests_total")
+	require.NoError(t, err)
+
+	count, err := testutil.GatherAndCount(registry, "song_library_http_request_duration_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+}
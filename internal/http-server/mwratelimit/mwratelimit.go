@@ -0,0 +1,108 @@
+package mwratelimit
+
+import (
+	"github.com/go-chi/chi/v5/middleware"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/mwauth"
+	"strconv"
+	"time"
+)
+
+// KeyFunc returns the key of the bucket the request takes a token from.
+type KeyFunc func(r *http.Request) string
+
+// New returns token bucket rate limiter middleware, requests are limited by the key.
+// Limited requests get 429 Too Many Requests with Retry-After header,
+// all responses get RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers.
+// If the limit rate is zero, the middleware is disabled.
+func New(log *slog.Logger, store Store, limit Limit, key KeyFunc) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		const op = "middleware.ratelimit"
+
+		log := log.With(slog.String("op", op))
+
+		if limit.Rate <= 0 {
+			log.Info("Rate limiter middleware disabled")
+
+			return next
+		}
+
+		log.Info("Rate limiter middleware enabled",
+			slog.Float64("rate", limit.Rate),
+			slog.Int("burst", limit.Burst))
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key := key(r)
+
+			result, err := store.Take(key, limit, time.Now())
+			if err != nil {
+				// Fail open: an unavailable store must not stop the service
+				log.ErrorContext(r.Context(), "Failed to take rate limit token",
+					slog.String("request_id", middleware.GetReqID(r.Context())),
+					slog.Any("error", err))
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			w.Header().Set("RateLimit-Reset", ceilSeconds(result.Reset))
+
+			if !result.Allowed {
+				log.InfoContext(r.Context(), "Too many requests",
+					slog.String("request_id", middleware.GetReqID(r.Context())),
+					slog.String("client", key))
+
+				w.Header().Set("Retry-After", ceilSeconds(result.RetryAfter))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// ByIP limits the requests by IP address, so it must be used after middleware.RealIP.
+// It limits the requests with invalid credentials too if it is used before mwauth.New.
+func ByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		// middleware.RealIP sets RemoteAddr without port
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// ByClient limits authenticated clients by API key or JWT subject and anonymous clients
+// by IP address, so it must be used after mwauth.New.
+func ByClient(r *http.Request) string {
+	principal, ok := mwauth.PrincipalFrom(r.Context())
+	if !ok {
+		return ByIP(r)
+	}
+
+	return ClientKey(principal)
+}
+
+// ClientKey returns the key of the authenticated client: the API key id or the JWT subject.
+// Names of API keys are not unique, so they are not used.
+func ClientKey(principal auth.Principal) string {
+	if principal.APIKeyID != 0 {
+		return "apikey:" + strconv.Itoa(principal.APIKeyID)
+	}
+
+	return "sub:" + principal.Subject
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
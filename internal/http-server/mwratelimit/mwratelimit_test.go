@@ -0,0 +1,125 @@
+package mwratelimit
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/auth"
+	"song-library/internal/http-server/mwauth"
+	"song-library/internal/logger/slogdiscard"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Rate: 1, Burst: 2}
+	now := time.Now()
+
+	result, err := store.Take("client", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Equal(t, 1, result.Remaining)
+
+	result, err = store.Take("client", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+	require.Equal(t, 2*time.Second, result.Reset)
+
+	// Bucket is empty
+	result, err = store.Take("client", limit, now.Add(500*time.Millisecond))
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	// Other clients have their own buckets
+	result, err = store.Take("other", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	// One token is refilled
+	result, err = store.Take("client", limit, now.Add(time.Second))
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := New(slogdiscard.NewDiscardLogger(), NewMemoryStore(), Limit{Rate: 0.5, Burst: 2}, ByIP)(next)
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, "/songs", nil)
+		require.NoError(t, err)
+		req.RemoteAddr = remoteAddr
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		return rr
+	}
+
+	rr := request("192.0.2.1:1234")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "2", rr.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", rr.Header().Get("RateLimit-Remaining"))
+
+	rr = request("192.0.2.1:5678")
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "0", rr.Header().Get("RateLimit-Remaining"))
+
+	rr = request("192.0.2.1:1234")
+	require.Equal(t, http.StatusTooManyRequests, rr.Code)
+	require.Equal(t, "2", rr.Header().Get("Retry-After"))
+	require.Equal(t, "4", rr.Header().Get("RateLimit-Reset"))
+
+	// Other address is not limited
+	rr = request("192.0.2.2:1234")
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestRateLimitMiddleware_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := New(slogdiscard.NewDiscardLogger(), NewMemoryStore(), Limit{}, ByIP)(next)
+
+	req, err := http.NewRequest(http.MethodGet, "/songs", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Empty(t, rr.Header().Get("RateLimit-Limit"))
+}
+
+func TestByClient(t *testing.T) {
+	key := func(principal *auth.Principal) string {
+		req, err := http.NewRequest(http.MethodGet, "/songs", nil)
+		require.NoError(t, err)
+		req.RemoteAddr = "192.0.2.1:1234"
+
+		if principal != nil {
+			req = req.WithContext(mwauth.WithPrincipal(req.Context(), *principal))
+		}
+
+		return ByClient(req)
+	}
+
+	require.Equal(t, "ip:192.0.2.1", key(nil))
+	require.Equal(t, "sub:alice", key(&auth.Principal{Subject: "alice", Role: auth.RoleEditor}))
+
+	// API keys with the same name are different clients
+	first := auth.APIKeyPrincipal(1, "importer")
+	second := auth.APIKeyPrincipal(2, "importer")
+	require.NotEqual(t, key(&first), key(&second))
+
+	// A JWT subject does not share the bucket of an API key
+	jwtPrincipal := auth.Principal{Subject: first.Subject}
+	require.NotEqual(t, key(&first), key(&jwtPrincipal))
+}
@@ -0,0 +1,59 @@
+package mwtracing
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"song-library/internal/http-server/mwlogger"
+	"song-library/internal/tracing"
+)
+
+// New returns middleware that starts a server span for every request.
+// The parent span is taken from the W3C traceparent header.
+// The span is named after the chi route pattern, e.g. "GET /songs/text".
+func New(provider trace.TracerProvider, propagator propagation.TextMapPropagator) func(next http.Handler) http.Handler {
+	tracer := provider.Tracer(tracing.InstrumentationName)
+
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			ctx, span := tracer.Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+					semconv.UserAgentOriginal(r.UserAgent()),
+					attribute.String("request_id", middleware.GetReqID(r.Context())),
+				),
+			)
+			defer span.End()
+
+			ww := mwlogger.WrapResponseWriter(w, r)
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+				span.SetName(r.Method + " " + routeCtx.RoutePattern())
+				span.SetAttributes(semconv.HTTPRoute(routeCtx.RoutePattern()))
+			}
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
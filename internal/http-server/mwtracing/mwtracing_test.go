@@ -0,0 +1,63 @@
+package mwtracing
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var handlerSpan trace.SpanContext
+
+	router := chi.NewRouter()
+	router.Use(New(provider, propagation.TraceContext{}))
+	router.Get("/songs/text", func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Delete("/songs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	// Request with the parent span from the client
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	req, err := http.NewRequest(http.MethodGet, "/songs/text?page=1", nil)
+	require.NoError(t, err)
+	req.Header.Set("traceparent", traceparent)
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	req, err = http.NewRequest(http.MethodDelete, "/songs", nil)
+	require.NoError(t, err)
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	getSpan := spans[0]
+	require.Equal(t, "GET /songs/text", getSpan.Name())
+	require.Equal(t, trace.SpanKindServer, getSpan.SpanKind())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", getSpan.SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", getSpan.Parent().SpanID().String())
+	require.Equal(t, getSpan.SpanContext(), handlerSpan)
+	require.Contains(t, getSpan.Attributes(), semconv.HTTPRoute("/songs/text"))
+	require.Contains(t, getSpan.Attributes(), semconv.HTTPResponseStatusCode(http.StatusOK))
+
+	deleteSpan := spans[1]
+	require.Equal(t, "DELETE /songs", deleteSpan.Name())
+	require.False(t, deleteSpan.Parent().IsValid())
+	require.Equal(t, codes.Error, deleteSpan.Status().Code)
+}
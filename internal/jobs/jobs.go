@@ -0,0 +1,301 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"maps"
+	"slices"
+	"song-library/internal/backoff"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"song-library/internal/tracing"
+	"sync"
+	"time"
+)
+
+// lockMargin is added to the job timeout when a job is claimed, so the job
+// is not claimed again while its worker is still recording the result.
+const lockMargin = 30 * time.Second
+
+// Handler runs a job of the kind it is registered for. The job is retried
+// if the handler fails, unless the error is wrapped by Permanent.
+type Handler func(ctx context.Context, job models.Job) error
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Store
+type Store interface {
+	EnqueueJob(ctx context.Context, kind string, payload []byte, maxAttempts int) (jobID int64, err error)
+	ClaimJob(ctx context.Context, kinds []string, lockFor time.Duration) (models.Job, error)
+	CompleteJob(ctx context.Context, jobID int64, attempt int) error
+	RetryJob(ctx context.Context, jobID int64, attempt int, jobErr string, runAt time.Time) error
+	DeadJob(ctx context.Context, jobID int64, attempt int, jobErr string) error
+}
+
+// Enqueuer stores background jobs, it is implemented by Pool.
+type Enqueuer interface {
+	Enqueue(ctx context.Context, kind string, payload any) (jobID int64, err error)
+}
+
+// Options of the pool.
+type Options struct {
+	// Workers is the number of jobs run at once
+	Workers int
+	// PollInterval is how often idle workers look for jobs enqueued by other processes
+	PollInterval time.Duration
+	// Timeout of a job attempt
+	Timeout time.Duration
+	// MaxAttempts of enqueued jobs, then they are dead
+	MaxAttempts int
+	// RetryBackoff is the delay before the second attempt, it doubles after each failed attempt
+	RetryBackoff time.Duration
+	// RetryMaxBackoff limits the delay between attempts
+	RetryMaxBackoff time.Duration
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent marks the error of a job that would fail again if it is retried,
+// e.g. a malformed payload. The job is dead at once.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether the error is marked by Permanent.
+func IsPermanent(err error) bool {
+	return errors.As(err, new(permanentError))
+}
+
+// Pool runs jobs stored in the jobs table by a fixed number of workers.
+// Workers poll the table for jobs of the registered kinds and are woken
+// at once when a job is enqueued by this process.
+type Pool struct {
+	store           Store
+	log             *slog.Logger
+	tracer          trace.Tracer
+	workers         int
+	pollInterval    time.Duration
+	timeout         time.Duration
+	maxAttempts     int
+	retryBackoff    time.Duration
+	retryMaxBackoff time.Duration
+	now             func() time.Time
+
+	handlers map[string]Handler
+	wake     chan struct{}
+	stop     chan struct{}
+	// jobsCtx is the parent of running jobs, it is cancelled if they outlive the shutdown timeout
+	jobsCtx    context.Context
+	cancelJobs context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+func New(opts Options, store Store, log *slog.Logger) *Pool {
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+
+	return &Pool{
+		store:           store,
+		log:             log.With(slog.String("op", "jobs")),
+		tracer:          otel.Tracer(tracing.InstrumentationName),
+		workers:         opts.Workers,
+		pollInterval:    opts.PollInterval,
+		timeout:         opts.Timeout,
+		maxAttempts:     opts.MaxAttempts,
+		retryBackoff:    opts.RetryBackoff,
+		retryMaxBackoff: opts.RetryMaxBackoff,
+		now:             time.Now,
+		handlers:        make(map[string]Handler),
+		wake:            make(chan struct{}, 1),
+		stop:            make(chan struct{}),
+		jobsCtx:         jobsCtx,
+		cancelJobs:      cancelJobs,
+	}
+}
+
+// Register sets the handler of the job kind, it must be called before Start.
+// Jobs of kinds without handlers are left in the table for other processes.
+func (p *Pool) Register(kind string, handler Handler) {
+	p.handlers[kind] = handler
+}
+
+// Enqueue stores a job of the kind, the payload is marshalled to JSON.
+func (p *Pool) Enqueue(ctx context.Context, kind string, payload any) (jobID int64, err error) {
+	const op = "jobs.Enqueue"
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to marshal payload: %w", op, err)
+	}
+
+	jobID, err = p.store.EnqueueJob(ctx, kind, data, p.maxAttempts)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+
+	return jobID, nil
+}
+
+// Start starts the workers.
+func (p *Pool) Start() {
+	if len(p.handlers) == 0 {
+		return
+	}
+
+	kinds := slices.Sorted(maps.Keys(p.handlers))
+
+	p.log.Info("Starting job workers", slog.Int("workers", p.workers), slog.Any("kinds", kinds))
+
+	for range p.workers {
+		p.wg.Add(1)
+
+		go func() {
+			defer p.wg.Done()
+
+			p.work(kinds)
+		}()
+	}
+}
+
+// Stop stops claiming new jobs and waits for the running ones. If ctx is done first,
+// the running jobs are cancelled and ctx error is returned, the jobs are retried later.
+func (p *Pool) Stop(ctx context.Context) error {
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancelJobs()
+		return nil
+	case <-ctx.Done():
+		p.cancelJobs()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work(kinds []string) {
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		job, err := p.store.ClaimJob(context.Background(), kinds, p.timeout+lockMargin)
+		if err == nil {
+			p.run(job)
+			continue
+		}
+
+		if !errors.Is(err, storage.ErrNoJobs) {
+			p.log.Error("Failed to claim job", slog.Any("error", err))
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-p.wake:
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// run runs the claimed job and records the result: a failed job is retried
+// with exponential backoff until it fails max attempts times, then it is dead.
+func (p *Pool) run(job models.Job) {
+	log := p.log.With(
+		slog.Int64("job_id", job.ID),
+		slog.String("kind", job.Kind),
+		slog.Int("attempt", job.Attempts))
+
+	ctx, span := p.tracer.Start(p.jobsCtx, "jobs."+job.Kind,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.Int64("job.id", job.ID),
+			attribute.Int("job.attempt", job.Attempts)))
+	defer span.End()
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	log.DebugContext(ctx, "Running job")
+
+	err := p.handle(ctx, job)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	// The result is recorded even if the job was cancelled on shutdown
+	ctx = context.WithoutCancel(ctx)
+
+	switch {
+	case err == nil:
+		log.InfoContext(ctx, "Job done")
+
+		err = p.store.CompleteJob(ctx, job.ID, job.Attempts)
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		log.ErrorContext(ctx, "Job failed, giving up", slog.Any("error", err))
+
+		err = p.store.DeadJob(ctx, job.ID, job.Attempts, err.Error())
+	default:
+		retryIn := backoff.Exponential(p.retryBackoff, p.retryMaxBackoff, job.Attempts)
+
+		log.WarnContext(ctx, "Job failed, retrying",
+			slog.Duration("retry_in", retryIn),
+			slog.Any("error", err))
+
+		err = p.store.RetryJob(ctx, job.ID, job.Attempts, err.Error(), p.now().Add(retryIn))
+	}
+
+	if errors.Is(err, storage.ErrJobLost) {
+		// The lock expired while the job was running, the next attempt records its own result
+		log.WarnContext(ctx, "Job was claimed again, result of the attempt is dropped")
+		return
+	}
+
+	if err != nil {
+		log.ErrorContext(ctx, "Failed to record job result", slog.Any("error", err))
+	}
+}
+
+// handle runs the handler of the job, a panic fails the attempt.
+func (p *Pool) handle(ctx context.Context, job models.Job) (err error) {
+	handler, ok := p.handlers[job.Kind]
+	if !ok {
+		return Permanent(fmt.Errorf("no handler of job kind %q", job.Kind))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
@@ -0,0 +1,176 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"song-library/internal/jobs/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"testing"
+	"time"
+)
+
+func newTestPool(store Store) *Pool {
+	return New(Options{
+		Workers:         2,
+		PollInterval:    10 * time.Millisecond,
+		MaxAttempts:     3,
+		RetryBackoff:    time.Second,
+		RetryMaxBackoff: 3 * time.Second,
+		Timeout:         time.Second,
+	}, store, slogdiscard.NewDiscardLogger())
+}
+
+func TestPoolRun(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		attempts int
+		err      error
+		panics   bool
+		expect   func(store *mocks.Store)
+	}{
+		{
+			name:     "Done",
+			attempts: 1,
+			expect: func(store *mocks.Store) {
+				store.On("CompleteJob", mock.Anything, int64(7), 1).Return(nil).Once()
+			},
+		},
+		{
+			name:     "Failed job is retried",
+			attempts: 1,
+			err:      errors.New("service unavailable"),
+			expect: func(store *mocks.Store) {
+				store.On("RetryJob", mock.Anything, int64(7), 1, "service unavailable", now.Add(time.Second)).
+					Return(nil).Once()
+			},
+		},
+		{
+			name:     "Backoff doubles",
+			attempts: 2,
+			err:      errors.New("service unavailable"),
+			expect: func(store *mocks.Store) {
+				store.On("RetryJob", mock.Anything, int64(7), 2, "service unavailable", now.Add(2*time.Second)).
+					Return(nil).Once()
+			},
+		},
+		{
+			name:     "Panic is retried",
+			attempts: 1,
+			panics:   true,
+			expect: func(store *mocks.Store) {
+				store.On("RetryJob", mock.Anything, int64(7), 1, "job panicked: boom", now.Add(time.Second)).
+					Return(nil).Once()
+			},
+		},
+		{
+			name:     "Dead after max attempts",
+			attempts: 3,
+			err:      errors.New("service unavailable"),
+			expect: func(store *mocks.Store) {
+				store.On("DeadJob", mock.Anything, int64(7), 3, "service unavailable").Return(nil).Once()
+			},
+		},
+		{
+			name:     "Permanent error is not retried",
+			attempts: 1,
+			err:      Permanent(errors.New("bad payload")),
+			expect: func(store *mocks.Store) {
+				store.On("DeadJob", mock.Anything, int64(7), 1, "bad payload").Return(nil).Once()
+			},
+		},
+		{
+			name:     "Job claimed again",
+			attempts: 1,
+			expect: func(store *mocks.Store) {
+				store.On("CompleteJob", mock.Anything, int64(7), 1).Return(storage.ErrJobLost).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := mocks.NewStore(t)
+			tc.expect(store)
+
+			pool := newTestPool(store)
+			pool.now = func() time.Time { return now }
+			pool.Register("test", func(ctx context.Context, job models.Job) error {
+				if tc.panics {
+					panic("boom")
+				}
+
+				return tc.err
+			})
+
+			pool.run(models.Job{ID: 7, Kind: "test", Attempts: tc.attempts, MaxAttempts: 3})
+		})
+	}
+}
+
+func TestPoolStartStop(t *testing.T) {
+	store := mocks.NewStore(t)
+
+	store.On("EnqueueJob", mock.Anything, "test", []byte(`{"group":"Muse","song":"Uprising"}`), 3).
+		Return(int64(7), nil).Once()
+	store.On("ClaimJob", mock.Anything, []string{"test"}, time.Second+lockMargin).
+		Return(models.Job{ID: 7, Kind: "test", Payload: []byte(`{"group":"Muse","song":"Uprising"}`),
+			Attempts: 1, MaxAttempts: 3}, nil).Once()
+	store.On("ClaimJob", mock.Anything, []string{"test"}, time.Second+lockMargin).
+		Return(models.Job{}, storage.ErrNoJobs)
+	store.On("CompleteJob", mock.Anything, int64(7), 1).Return(nil).Once()
+
+	done := make(chan models.Job, 1)
+
+	pool := newTestPool(store)
+	pool.Register("test", func(ctx context.Context, job models.Job) error {
+		done <- job
+		return nil
+	})
+
+	jobID, err := pool.Enqueue(context.Background(), "test", models.Song{GroupName: "Muse", SongName: "Uprising"})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), jobID)
+
+	pool.Start()
+
+	select {
+	case job := <-done:
+		require.Equal(t, int64(7), job.ID)
+	case <-time.After(time.Second):
+		t.Fatal("job is not run")
+	}
+
+	require.NoError(t, pool.Stop(context.Background()))
+}
+
+func TestPoolStopCancelsJobs(t *testing.T) {
+	store := mocks.NewStore(t)
+
+	store.On("ClaimJob", mock.Anything, []string{"test"}, time.Second+lockMargin).
+		Return(models.Job{ID: 7, Kind: "test", Attempts: 1, MaxAttempts: 3}, nil).Once()
+	store.On("RetryJob", mock.Anything, int64(7), 1, context.Canceled.Error(), mock.Anything).Return(nil).Once()
+
+	started := make(chan struct{})
+
+	pool := newTestPool(store)
+	pool.workers = 1
+	pool.Register("test", func(ctx context.Context, job models.Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	pool.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, pool.Stop(ctx), context.DeadlineExceeded)
+}
@@ -0,0 +1,142 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "song-library/internal/models"
+
+	time "time"
+)
+
+// Store is an autogenerated mock type for the Store type
+type Store struct {
+	mock.Mock
+}
+
+// ClaimJob provides a mock function with given fields: ctx, kinds, lockFor
+func (_m *Store) ClaimJob(ctx context.Context, kinds []string, lockFor time.Duration) (models.Job, error) {
+	ret := _m.Called(ctx, kinds, lockFor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimJob")
+	}
+
+	var r0 models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Duration) (models.Job, error)); ok {
+		return rf(ctx, kinds, lockFor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Duration) models.Job); ok {
+		r0 = rf(ctx, kinds, lockFor)
+	} else {
+		r0 = ret.Get(0).(models.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, time.Duration) error); ok {
+		r1 = rf(ctx, kinds, lockFor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteJob provides a mock function with given fields: ctx, jobID, attempt
+func (_m *Store) CompleteJob(ctx context.Context, jobID int64, attempt int) error {
+	ret := _m.Called(ctx, jobID, attempt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) error); ok {
+		r0 = rf(ctx, jobID, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeadJob provides a mock function with given fields: ctx, jobID, attempt, jobErr
+func (_m *Store) DeadJob(ctx context.Context, jobID int64, attempt int, jobErr string) error {
+	ret := _m.Called(ctx, jobID, attempt, jobErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeadJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, string) error); ok {
+		r0 = rf(ctx, jobID, attempt, jobErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnqueueJob provides a mock function with given fields: ctx, kind, payload, maxAttempts
+func (_m *Store) EnqueueJob(ctx context.Context, kind string, payload []byte, maxAttempts int) (int64, error) {
+	ret := _m.Called(ctx, kind, payload, maxAttempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueJob")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, int) (int64, error)); ok {
+		return rf(ctx, kind, payload, maxAttempts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, int) int64); ok {
+		r0 = rf(ctx, kind, payload, maxAttempts)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, int) error); ok {
+		r1 = rf(ctx, kind, payload, maxAttempts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryJob provides a mock function with given fields: ctx, jobID, attempt, jobErr, runAt
+func (_m *Store) RetryJob(ctx context.Context, jobID int64, attempt int, jobErr string, runAt time.Time) error {
+	ret := _m.Called(ctx, jobID, attempt, jobErr, runAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, string, time.Time) error); ok {
+		r0 = rf(ctx, jobID, attempt, jobErr, runAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Store {
+	mock := &Store{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
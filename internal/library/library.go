@@ -0,0 +1,63 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"song-library/internal/jobs"
+	"song-library/internal/models"
+	"song-library/internal/musicinfo"
+	"song-library/internal/storage"
+)
+
+// SongSaver finds and saves songs.
+type SongSaver interface {
+	SongInfo(ctx context.Context, groupName string, songName string) (models.SongDetail, error)
+	SaveSong(ctx context.Context, groupName string, songName string) (songID int, err error)
+}
+
+// SaveSong saves the new song for POST /songs, the gRPC and the GraphQL APIs,
+// storage.ErrSongExists is returned if the song is already saved.
+// If jobEnqueuer is not nil, the job filling the details of the song from the music info service
+// is enqueued and its id is returned. The song is saved even if the job is not enqueued,
+// its details are set by an update then, so the failure is only logged and the job id is zero.
+func SaveSong(ctx context.Context, log *slog.Logger, songSaver SongSaver, jobEnqueuer jobs.Enqueuer,
+	song models.Song) (jobID int64, err error) {
+	const op = "library.SaveSong"
+
+	_, err = songSaver.SongInfo(ctx, song.GroupName, song.SongName)
+	if err == nil {
+		return 0, storage.ErrSongExists
+	}
+
+	if !errors.Is(err, storage.ErrSongNotFound) {
+		return 0, fmt.Errorf("%s: failed to find song: %w", op, err)
+	}
+
+	songID, err := songSaver.SaveSong(ctx, song.GroupName, song.SongName)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to save song: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "Song successfully saved",
+		slog.String("group", song.GroupName),
+		slog.String("song", song.SongName),
+		slog.Int("song_id", songID))
+
+	if jobEnqueuer == nil {
+		return 0, nil
+	}
+
+	jobID, err = jobEnqueuer.Enqueue(ctx, musicinfo.JobKindEnrich, song)
+	if err != nil {
+		log.WarnContext(ctx, "Failed to enqueue song enrichment",
+			slog.String("group", song.GroupName),
+			slog.String("song", song.SongName),
+			slog.Any("error", err))
+
+		return 0, nil
+	}
+
+	return jobID, nil
+}
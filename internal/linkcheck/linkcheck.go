@@ -0,0 +1,281 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const userAgent = "song-library-linkcheck/1.0"
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Storage
+type Storage interface {
+	SongLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]models.SongLink, error)
+	SongLinkCheckSave(ctx context.Context, link models.SongLink, linkCheck models.LinkCheck) error
+}
+
+// Options of the checker.
+type Options struct {
+	// Interval between the checks, zero disables the checker
+	Interval time.Duration
+	// MaxAge is how long a link check is up to date
+	MaxAge time.Duration
+	// Concurrency is the number of links checked at once
+	Concurrency int
+	// Timeout of a link request
+	Timeout time.Duration
+	// BatchSize is the number of links read from the storage at once
+	BatchSize int
+}
+
+// Checker periodically checks song links that are not checked or checked
+// more than max age ago and records their status.
+type Checker struct {
+	client      *http.Client
+	storage     Storage
+	log         *slog.Logger
+	interval    time.Duration
+	maxAge      time.Duration
+	concurrency int
+	timeout     time.Duration
+	batchSize   int
+	now         func() time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func New(opts Options, storage Storage, log *slog.Logger) *Checker {
+	return &Checker{
+		client:      &http.Client{},
+		storage:     storage,
+		log:         log.With(slog.String("op", "linkcheck")),
+		interval:    opts.Interval,
+		maxAge:      opts.MaxAge,
+		concurrency: opts.Concurrency,
+		timeout:     opts.Timeout,
+		batchSize:   opts.BatchSize,
+		now:         time.Now,
+	}
+}
+
+// Start checks the links at once and then every interval until Stop.
+// Zero interval disables the checker.
+func (c *Checker) Start() {
+	if c.interval <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	c.log.Info("Starting dead link checker", slog.Duration("interval", c.interval))
+
+	go func() {
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := c.CheckDue(ctx); err != nil && ctx.Err() == nil {
+				c.log.Error("Failed to check links", slog.Any("error", err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop cancels the running checks and waits for the checker to stop,
+// the cancelled links are checked on the next start.
+func (c *Checker) Stop() {
+	if c.cancel == nil {
+		return
+	}
+
+	c.cancel()
+	<-c.done
+}
+
+// CheckDue checks the links due for a check in batches
+// and returns the number of checked links.
+func (c *Checker) CheckDue(ctx context.Context) (checked int, err error) {
+	checkedBefore := c.now().Add(-c.maxAge)
+
+	for {
+		links, err := c.storage.SongLinksToCheck(ctx, checkedBefore, c.batchSize)
+		if err != nil {
+			return checked, err
+		}
+
+		saved := c.checkAll(ctx, links)
+
+		if ctx.Err() != nil {
+			return checked, ctx.Err()
+		}
+
+		// Links not saved are returned again, the batch is repeated only if it made progress
+		if saved == 0 && len(links) > 0 {
+			return checked, errors.New("no link check of the batch is saved")
+		}
+
+		checked += saved
+
+		if len(links) < c.batchSize {
+			c.log.Info("Links checked", slog.Int("checked", checked))
+
+			return checked, nil
+		}
+	}
+}
+
+// checkAll checks the links by at most 'concurrency' requests at a time, saves the results
+// and returns the number of links that are done, i.e. saved or discarded.
+func (c *Checker) checkAll(ctx context.Context, links []models.SongLink) int {
+	queue := make(chan models.SongLink)
+
+	var wg sync.WaitGroup
+	var saved atomic.Int32
+
+	for range min(c.concurrency, len(links)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for link := range queue {
+				if c.checkAndSave(ctx, link) {
+					saved.Add(1)
+				}
+			}
+		}()
+	}
+
+	for _, link := range links {
+		select {
+		case queue <- link:
+		case <-ctx.Done():
+		}
+	}
+
+	close(queue)
+	wg.Wait()
+
+	return int(saved.Load())
+}
+
+func (c *Checker) checkAndSave(ctx context.Context, link models.SongLink) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	linkCheck := c.Check(ctx, link.Link)
+
+	// A check cancelled on shutdown is not a result
+	if ctx.Err() != nil {
+		return false
+	}
+
+	log := c.log.With(
+		slog.Int("song_id", link.SongID),
+		slog.String("link", link.Link),
+		slog.String("status", string(linkCheck.Status)))
+
+	err := c.storage.SongLinkCheckSave(ctx, link, linkCheck)
+	if err != nil {
+		if errors.Is(err, storage.ErrSongNotFound) {
+			log.DebugContext(ctx, "Song is deleted or its link is changed, the check is discarded")
+			return true
+		}
+
+		log.ErrorContext(ctx, "Failed to save link check", slog.Any("error", err))
+
+		return false
+	}
+
+	if linkCheck.Status != models.LinkOK {
+		log.InfoContext(ctx, "Dead link found", slog.Int("status_code", linkCheck.StatusCode))
+	}
+
+	return true
+}
+
+// Check requests the link with HEAD, or with GET if the server does not support HEAD.
+// Redirects are followed, the final URL is recorded. Client errors mean the link is broken,
+// network errors, timeouts, server errors and rate limiting mean the link is unreachable.
+func (c *Checker) Check(ctx context.Context, link string) models.LinkCheck {
+	linkCheck := models.LinkCheck{CheckedAt: c.now()}
+
+	linkURL, err := url.Parse(link)
+	if err != nil || (linkURL.Scheme != "http" && linkURL.Scheme != "https") || linkURL.Host == "" {
+		linkCheck.Status = models.LinkBroken
+		return linkCheck
+	}
+
+	resp, err := c.request(ctx, http.MethodHead, link)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp, err = c.request(ctx, http.MethodGet, link)
+	}
+
+	if err != nil {
+		linkCheck.Status = models.LinkUnreachable
+		return linkCheck
+	}
+
+	linkCheck.StatusCode = resp.StatusCode
+
+	if finalURL := resp.Request.URL.String(); finalURL != link {
+		linkCheck.RedirectTo = finalURL
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		linkCheck.Status = models.LinkOK
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests:
+		linkCheck.Status = models.LinkUnreachable
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		linkCheck.Status = models.LinkBroken
+	default:
+		linkCheck.Status = models.LinkUnreachable
+	}
+
+	return linkCheck
+}
+
+// request sends the request, the body of the response is discarded.
+func (c *Checker) request(ctx context.Context, method string, link string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// A small part of the body is read, so the connection may be reused
+	_, _ = io.CopyN(io.Discard, resp.Body, 64<<10)
+	_ = resp.Body.Close()
+
+	return resp, nil
+}
@@ -0,0 +1,199 @@
+package linkcheck
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/linkcheck/mocks"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestChecker(storage Storage) *Checker {
+	return New(Options{
+		Interval:    time.Hour,
+		MaxAge:      24 * time.Hour,
+		Concurrency: 2,
+		Timeout:     100 * time.Millisecond,
+		BatchSize:   2,
+	}, storage, slogdiscard.NewDiscardLogger())
+}
+
+// newVideoServer serves the videos of the test links, the path selects the response.
+func newVideoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/removed", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/watch", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	mux.HandleFunc("/busy", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestCheck(t *testing.T) {
+	server := newVideoServer(t)
+
+	cases := []struct {
+		name       string
+		link       string
+		status     models.LinkStatus
+		statusCode int
+		redirectTo string
+	}{
+		{name: "Ok", link: server.URL + "/watch", status: models.LinkOK, statusCode: http.StatusOK},
+		{name: "Removed", link: server.URL + "/removed", status: models.LinkBroken, statusCode: http.StatusGone},
+		{name: "Not found", link: server.URL + "/unknown", status: models.LinkBroken, statusCode: http.StatusNotFound},
+		{
+			name:       "Redirect",
+			link:       server.URL + "/moved",
+			status:     models.LinkOK,
+			statusCode: http.StatusOK,
+			redirectTo: server.URL + "/watch",
+		},
+		{name: "HEAD not allowed", link: server.URL + "/get-only", status: models.LinkOK, statusCode: http.StatusOK},
+		{name: "Server error", link: server.URL + "/error", status: models.LinkUnreachable, statusCode: http.StatusBadGateway},
+		{name: "Rate limited", link: server.URL + "/busy", status: models.LinkUnreachable, statusCode: http.StatusTooManyRequests},
+		{name: "Timeout", link: server.URL + "/slow", status: models.LinkUnreachable},
+		{name: "Connection refused", link: "http://127.0.0.1:1/watch", status: models.LinkUnreachable},
+		{name: "Not a web link", link: "ftp://example.com/song.mp3", status: models.LinkBroken},
+		{name: "Incorrect link", link: "not a link", status: models.LinkBroken},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+			checker := newTestChecker(nil)
+			checker.now = func() time.Time { return now }
+
+			linkCheck := checker.Check(context.Background(), tc.link)
+
+			require.Equal(t, models.LinkCheck{
+				Status:     tc.status,
+				StatusCode: tc.statusCode,
+				RedirectTo: tc.redirectTo,
+				CheckedAt:  now,
+			}, linkCheck)
+		})
+	}
+}
+
+func TestCheckDue(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+
+		if r.URL.Path == "/removed" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	firstBatch := []models.SongLink{
+		{SongID: 1, Link: server.URL + "/watch?v=1"},
+		{SongID: 2, Link: server.URL + "/removed"},
+	}
+	secondBatch := []models.SongLink{
+		{SongID: 3, Link: server.URL + "/watch?v=3"},
+	}
+
+	storageMock := mocks.NewStorage(t)
+
+	storageMock.On("SongLinksToCheck", mock.Anything, now.Add(-24*time.Hour), 2).
+		Return(firstBatch, nil).Once()
+	storageMock.On("SongLinksToCheck", mock.Anything, now.Add(-24*time.Hour), 2).
+		Return(secondBatch, nil).Once()
+
+	for _, link := range append(firstBatch, secondBatch...) {
+		status, statusCode := models.LinkOK, http.StatusOK
+		if link.SongID == 2 {
+			status, statusCode = models.LinkBroken, http.StatusNotFound
+		}
+
+		storageMock.On("SongLinkCheckSave", mock.Anything, link, models.LinkCheck{
+			Status:     status,
+			StatusCode: statusCode,
+			CheckedAt:  now,
+		}).Return(nil).Once()
+	}
+
+	checker := newTestChecker(storageMock)
+	checker.now = func() time.Time { return now }
+
+	checked, err := checker.CheckDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, checked)
+	require.LessOrEqual(t, maxInFlight.Load(), int32(2))
+}
+
+func TestCheckDueStopsWithoutProgress(t *testing.T) {
+	server := newVideoServer(t)
+
+	links := []models.SongLink{
+		{SongID: 1, Link: server.URL + "/watch"},
+		{SongID: 2, Link: server.URL + "/watch"},
+	}
+
+	storageMock := mocks.NewStorage(t)
+
+	storageMock.On("SongLinksToCheck", mock.Anything, mock.Anything, 2).Return(links, nil).Once()
+	storageMock.On("SongLinkCheckSave", mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("connection lost")).Twice()
+
+	checker := newTestChecker(storageMock)
+
+	checked, err := checker.CheckDue(context.Background())
+	require.Error(t, err)
+	require.Zero(t, checked)
+}
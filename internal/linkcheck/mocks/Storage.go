@@ -0,0 +1,80 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "song-library/internal/models"
+
+	time "time"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// SongLinkCheckSave provides a mock function with given fields: ctx, link, linkCheck
+func (_m *Storage) SongLinkCheckSave(ctx context.Context, link models.SongLink, linkCheck models.LinkCheck) error {
+	ret := _m.Called(ctx, link, linkCheck)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SongLinkCheckSave")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SongLink, models.LinkCheck) error); ok {
+		r0 = rf(ctx, link, linkCheck)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SongLinksToCheck provides a mock function with given fields: ctx, checkedBefore, limit
+func (_m *Storage) SongLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]models.SongLink, error) {
+	ret := _m.Called(ctx, checkedBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SongLinksToCheck")
+	}
+
+	var r0 []models.SongLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]models.SongLink, error)); ok {
+		return rf(ctx, checkedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []models.SongLink); ok {
+		r0 = rf(ctx, checkedBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SongLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, checkedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Storage {
+	mock := &Storage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
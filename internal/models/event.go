@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// SongEvent is an entry of the song event log streamed by GET /events.
+type SongEvent struct {
+	ID         int64     `json:"id"`
+	Event      string    `json:"event"`
+	Song       Song      `json:"song"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
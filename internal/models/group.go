@@ -0,0 +1,5 @@
+package models
+
+type Group struct {
+	Name string `json:"name"`
+}
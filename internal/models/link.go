@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type LinkStatus string
+
+const (
+	LinkOK LinkStatus = "ok"
+	// LinkBroken is a link the server responded to with a client error, e.g. 404 or 410
+	LinkBroken LinkStatus = "broken"
+	// LinkUnreachable is a link that failed with a network error, a timeout or a server error
+	LinkUnreachable LinkStatus = "unreachable"
+	// LinkUnchecked is not stored, it filters songs whose links are not checked yet
+	LinkUnchecked LinkStatus = "unchecked"
+)
+
+// LinkCheck is the result of the last check of a song link.
+type LinkCheck struct {
+	Status LinkStatus `json:"status"`
+	// StatusCode of the response, missing if the request failed
+	StatusCode int `json:"statusCode,omitempty"`
+	// RedirectTo is the final URL if the link redirects
+	RedirectTo string    `json:"redirectTo,omitempty"`
+	CheckedAt  time.Time `json:"checkedAt"`
+}
+
+// SongLink is a link to be checked.
+type SongLink struct {
+	SongID int
+	Link   string
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// OutboxEvent is a song lifecycle event published by the outbox relay.
+// The id is kept when the event is published again after a failure,
+// so consumers skip the events they have already processed.
+type OutboxEvent struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Song       Song      `json:"song"`
+	// Attempts is the number of publishing attempts including the current one
+	Attempts int `json:"-"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Song struct {
 	GroupName string `json:"group" validate:"required"`
 	SongName  string `json:"song" validate:"required"`
@@ -9,9 +11,22 @@ type SongDetail struct {
 	ReleaseDate string `json:"releaseDate" validate:"required"`
 	Text        string `json:"text" validate:"required"`
 	Link        string `json:"link" validate:"required"`
+	// UpdatedAt is sent in the Last-Modified header
+	UpdatedAt time.Time `json:"-"`
 }
 
 type SongWithDetail struct {
 	Song
 	SongDetail SongDetail `json:"songDetail" validate:"required"`
+	// LinkCheck is missing if the link is not checked yet
+	LinkCheck *LinkCheck `json:"linkCheck,omitempty"`
+}
+
+type SongTranslation struct {
+	Song
+	// Lang is a BCP 47 language tag, e.g. en, de-AT
+	Lang string `json:"lang" validate:"required"`
+	Text string `json:"text" validate:"required"`
+	// UpdatedAt is the modification time of the song, it changes with its translations
+	UpdatedAt time.Time `json:"-"`
 }
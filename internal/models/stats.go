@@ -0,0 +1,7 @@
+package models
+
+type LibraryStats struct {
+	Songs        int `json:"songs"`
+	Groups       int `json:"groups"`
+	Translations int `json:"translations"`
+}
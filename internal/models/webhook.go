@@ -0,0 +1,57 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Song lifecycle events sent to webhooks
+const (
+	EventSongCreated = "song.created"
+	EventSongUpdated = "song.updated"
+	EventSongDeleted = "song.deleted"
+)
+
+// Events are all events webhooks may subscribe to
+var Events = []string{EventSongCreated, EventSongUpdated, EventSongDeleted}
+
+type Webhook struct {
+	ID     int      `json:"id"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret signs the payloads, it is never sent back to clients
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// DeliveryFailed is a delivery that is not retried any more
+	DeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID        int64                 `json:"id"`
+	WebhookID int                   `json:"webhookId"`
+	Event     string                `json:"event"`
+	Payload   json.RawMessage       `json:"payload"`
+	Status    WebhookDeliveryStatus `json:"status"`
+	Attempts  int                   `json:"attempts"`
+	// ResponseCode of the last attempt, missing if the request failed
+	ResponseCode int       `json:"responseCode,omitempty"`
+	LastError    string    `json:"lastError,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// WebhookEvent is the body of webhook requests.
+type WebhookEvent struct {
+	// ID is the id of the outbox event, receivers use it to skip duplicates
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Song       Song      `json:"song"`
+}
@@ -0,0 +1,168 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"song-library/internal/config"
+	"song-library/internal/models"
+	"time"
+)
+
+var (
+	ErrSongNotFound = errors.New("song not found in music info service")
+	ErrCircuitOpen  = errors.New("music info service is unavailable, circuit breaker is open")
+)
+
+// errBadResponse is a response that does not match the API, it is not retried.
+var errBadResponse = errors.New("bad response")
+
+// statusError is an unexpected status code of the music info service.
+type statusError struct {
+	code int
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.code)
+}
+
+// Client gets song details from the external music info service,
+// the API is described in swagger/musicinfo.yaml.
+type Client struct {
+	baseURL      *url.URL
+	httpClient   *http.Client
+	timeout      time.Duration
+	retries      int
+	retryBackoff time.Duration
+	breaker      *breaker
+	log          *slog.Logger
+}
+
+func New(cfg *config.Config, log *slog.Logger) (*Client, error) {
+	const op = "musicinfo.New"
+
+	baseURL, err := url.Parse(cfg.MusicInfoURL)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("%s: incorrect base URL %q", op, cfg.MusicInfoURL)
+	}
+
+	return &Client{
+		baseURL:      baseURL,
+		httpClient:   &http.Client{},
+		timeout:      cfg.MusicInfoTimeout,
+		retries:      cfg.MusicInfoRetries,
+		retryBackoff: cfg.MusicInfoRetryBackoff,
+		breaker:      newBreaker(cfg.MusicInfoBreakerThreshold, cfg.MusicInfoBreakerCooldown),
+		log:          log.With(slog.String("op", "musicinfo")),
+	}, nil
+}
+
+// SongDetail gets the details of the song. Network errors, 5xx and 429 responses
+// are retried with exponential backoff. ErrSongNotFound is returned if the service
+// does not know the song, ErrCircuitOpen if the service failed too many times in a row.
+func (c *Client) SongDetail(ctx context.Context, groupName string, songName string) (models.SongDetail, error) {
+	const op = "musicinfo.SongDetail"
+
+	if !c.breaker.allow() {
+		return models.SongDetail{}, ErrCircuitOpen
+	}
+
+	backoff := c.retryBackoff
+
+	for attempt := 0; ; attempt++ {
+		songDetail, err := c.get(ctx, groupName, songName)
+		if err == nil || errors.Is(err, ErrSongNotFound) {
+			c.breaker.success()
+			return songDetail, err
+		}
+
+		if !retryable(err) {
+			// The service is up, the request or the response is wrong
+			c.breaker.success()
+			return models.SongDetail{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if attempt >= c.retries || ctx.Err() != nil {
+			c.breaker.failure()
+			return models.SongDetail{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		c.log.WarnContext(ctx, "Music info request failed, retrying",
+			slog.Int("attempt", attempt+1),
+			slog.Duration("retry_in", backoff),
+			slog.Any("error", err))
+
+		select {
+		case <-ctx.Done():
+			c.breaker.failure()
+			return models.SongDetail{}, fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+func (c *Client) get(ctx context.Context, groupName string, songName string) (songDetail models.SongDetail, err error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	queryParameters := url.Values{}
+	queryParameters.Add("group", groupName)
+	queryParameters.Add("song", songName)
+
+	getURL := c.baseURL.JoinPath("info")
+	getURL.RawQuery = queryParameters.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL.String(), nil)
+	if err != nil {
+		return songDetail, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return songDetail, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		err = json.NewDecoder(resp.Body).Decode(&songDetail)
+		if err != nil {
+			return songDetail, fmt.Errorf("%w: failed to decode song detail: %v", errBadResponse, err)
+		}
+
+		return songDetail, nil
+	case http.StatusNotFound, http.StatusNoContent:
+		return songDetail, ErrSongNotFound
+	default:
+		return songDetail, statusError{code: resp.StatusCode}
+	}
+}
+
+// retryable reports whether the request may succeed if it is repeated:
+// network errors, timeouts, server errors and rate limiting.
+func retryable(err error) bool {
+	if errors.Is(err, errBadResponse) {
+		return false
+	}
+
+	var se statusError
+	if errors.As(err, &se) {
+		return se.code >= http.StatusInternalServerError || se.code == http.StatusTooManyRequests
+	}
+
+	return true
+}
@@ -0,0 +1,246 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/config"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/musicinfo/mocks"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var songDetail = models.SongDetail{
+	ReleaseDate: "16.07.2006",
+	Text:        "Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?",
+	Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+}
+
+// fakeService stands in for the music info service described in swagger/musicinfo.yaml.
+// It fails the first 'failures' requests with 'failStatus'.
+type fakeService struct {
+	songs      map[[2]string]models.SongDetail
+	failures   int32
+	failStatus int
+	delay      time.Duration
+	requests   atomic.Int32
+}
+
+func (f *fakeService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := f.requests.Add(1)
+
+	if r.Method != http.MethodGet || r.URL.Path != "/info" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	time.Sleep(f.delay)
+
+	if n <= f.failures {
+		w.WriteHeader(f.failStatus)
+		return
+	}
+
+	group, song := r.URL.Query().Get("group"), r.URL.Query().Get("song")
+	if group == "" || song == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	detail, ok := f.songs[[2]string{group, song}]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(detail)
+}
+
+func newTestClient(t *testing.T, service *fakeService) *Client {
+	t.Helper()
+
+	if service.songs == nil {
+		service.songs = map[[2]string]models.SongDetail{{"Muse", "Supermassive Black Hole"}: songDetail}
+	}
+
+	server := httptest.NewServer(service)
+	t.Cleanup(server.Close)
+
+	client, err := New(&config.Config{
+		MusicInfoURL:              server.URL,
+		MusicInfoTimeout:          100 * time.Millisecond,
+		MusicInfoRetries:          2,
+		MusicInfoRetryBackoff:     time.Millisecond,
+		MusicInfoBreakerThreshold: 2,
+		MusicInfoBreakerCooldown:  time.Minute,
+	}, slogdiscard.NewDiscardLogger())
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestClientSongDetail(t *testing.T) {
+	cases := []struct {
+		name     string
+		service  *fakeService
+		group    string
+		song     string
+		detail   models.SongDetail
+		err      error
+		anyErr   bool
+		requests int32
+	}{
+		{
+			name:     "Success",
+			group:    "Muse",
+			song:     "Supermassive Black Hole",
+			detail:   songDetail,
+			requests: 1,
+		},
+		{
+			name:     "Not found",
+			group:    "Muse",
+			song:     "Unknown",
+			err:      ErrSongNotFound,
+			requests: 1,
+		},
+		{
+			name:     "Server errors are retried",
+			service:  &fakeService{failures: 2, failStatus: http.StatusServiceUnavailable},
+			group:    "Muse",
+			song:     "Supermassive Black Hole",
+			detail:   songDetail,
+			requests: 3,
+		},
+		{
+			name:     "Too many requests are retried",
+			service:  &fakeService{failures: 1, failStatus: http.StatusTooManyRequests},
+			group:    "Muse",
+			song:     "Supermassive Black Hole",
+			detail:   songDetail,
+			requests: 2,
+		},
+		{
+			name:     "Retries are limited",
+			service:  &fakeService{failures: 10, failStatus: http.StatusInternalServerError},
+			group:    "Muse",
+			song:     "Supermassive Black Hole",
+			anyErr:   true,
+			requests: 3,
+		},
+		{
+			name:     "Bad request is not retried",
+			group:    "",
+			song:     "Supermassive Black Hole",
+			anyErr:   true,
+			requests: 1,
+		},
+		{
+			name:     "Timeout",
+			service:  &fakeService{delay: 500 * time.Millisecond},
+			group:    "Muse",
+			song:     "Supermassive Black Hole",
+			anyErr:   true,
+			requests: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.service == nil {
+				tc.service = &fakeService{}
+			}
+
+			client := newTestClient(t, tc.service)
+
+			detail, err := client.SongDetail(context.Background(), tc.group, tc.song)
+
+			switch {
+			case tc.err != nil:
+				require.ErrorIs(t, err, tc.err)
+			case tc.anyErr:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+				require.Equal(t, tc.detail, detail)
+			}
+
+			require.Equal(t, tc.requests, tc.service.requests.Load())
+		})
+	}
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	service := &fakeService{failures: 100, failStatus: http.StatusInternalServerError}
+	client := newTestClient(t, service)
+
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	// Two failed calls in a row open the breaker
+	for range 2 {
+		_, err := client.SongDetail(context.Background(), "Muse", "Supermassive Black Hole")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+
+	requests := service.requests.Load()
+
+	_, err := client.SongDetail(context.Background(), "Muse", "Supermassive Black Hole")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.Equal(t, requests, service.requests.Load())
+
+	// After the cooldown a trial call is made, it succeeds and closes the breaker
+	service.failures = 0
+	now = now.Add(time.Minute)
+
+	detail, err := client.SongDetail(context.Background(), "Muse", "Supermassive Black Hole")
+	require.NoError(t, err)
+	require.Equal(t, songDetail, detail)
+
+	_, err = client.SongDetail(context.Background(), "Muse", "Supermassive Black Hole")
+	require.NoError(t, err)
+}
+
+func TestEnricher(t *testing.T) {
+	cases := []struct {
+		name       string
+		song       string
+		storageErr error
+		enriched   bool
+		err        bool
+	}{
+		{name: "Enriched", song: "Supermassive Black Hole", enriched: true},
+		{name: "Unknown song is left as is", song: "Unknown"},
+		{name: "Storage error", song: "Supermassive Black Hole", storageErr: errors.New("internal error"), enriched: true, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestClient(t, &fakeService{})
+
+			storageMock := mocks.NewSongEnricher(t)
+			if tc.enriched {
+				storageMock.On("SongEnrich", mock.Anything, "Muse", tc.song, songDetail).
+					Return(tc.storageErr).Once()
+			}
+
+			enricher := NewEnricher(client, storageMock, slogdiscard.NewDiscardLogger())
+
+			err := enricher.Enrich(context.Background(), "Muse", tc.song)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
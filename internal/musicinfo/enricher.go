@@ -0,0 +1,91 @@
+package musicinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"song-library/internal/jobs"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+)
+
+// JobKindEnrich is the kind of jobs enriching saved songs, the payload is models.Song.
+const JobKindEnrich = "song.enrich"
+
+type SongDetailer interface {
+	SongDetail(ctx context.Context, groupName string, songName string) (models.SongDetail, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=SongEnricher
+type SongEnricher interface {
+	SongEnrich(ctx context.Context, groupName string, songName string, songDetail models.SongDetail) error
+}
+
+// Enricher fills the details of saved songs from the music info service.
+type Enricher struct {
+	client  SongDetailer
+	storage SongEnricher
+	log     *slog.Logger
+}
+
+func NewEnricher(client SongDetailer, storage SongEnricher, log *slog.Logger) *Enricher {
+	return &Enricher{
+		client:  client,
+		storage: storage,
+		log:     log.With(slog.String("op", "musicinfo.enricher")),
+	}
+}
+
+// Enrich fetches the details of the song and fills its empty fields,
+// the details already set by clients are not overwritten.
+// A song unknown to the service is left as is.
+func (e *Enricher) Enrich(ctx context.Context, groupName string, songName string) error {
+	const op = "musicinfo.Enrich"
+
+	songDetail, err := e.client.SongDetail(ctx, groupName, songName)
+	if err != nil {
+		if errors.Is(err, ErrSongNotFound) {
+			e.log.InfoContext(ctx, "Song is unknown to music info service",
+				slog.String("group", groupName),
+				slog.String("song", songName))
+
+			return nil
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = e.storage.SongEnrich(ctx, groupName, songName, songDetail); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	e.log.InfoContext(ctx, "Song enriched",
+		slog.String("group", groupName),
+		slog.String("song", songName))
+
+	return nil
+}
+
+// HandleJob is the jobs.Handler of JobKindEnrich jobs. Errors of the service
+// are retried by the job queue, a song deleted before the job ran is skipped.
+func (e *Enricher) HandleJob(ctx context.Context, job models.Job) error {
+	const op = "musicinfo.HandleJob"
+
+	var song models.Song
+	if err := json.Unmarshal(job.Payload, &song); err != nil {
+		return jobs.Permanent(fmt.Errorf("%s: failed to decode payload: %w", op, err))
+	}
+
+	err := e.Enrich(ctx, song.GroupName, song.SongName)
+	if errors.Is(err, storage.ErrSongNotFound) {
+		e.log.InfoContext(ctx, "Song is deleted, nothing to enrich",
+			slog.String("group", song.GroupName),
+			slog.String("song", song.SongName))
+
+		return nil
+	}
+
+	return err
+}
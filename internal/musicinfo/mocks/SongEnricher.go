@@ -0,0 +1,47 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "song-library/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SongEnricher is an autogenerated mock type for the SongEnricher type
+type SongEnricher struct {
+	mock.Mock
+}
+
+// SongEnrich provides a mock function with given fields: ctx, groupName, songName, songDetail
+func (_m *SongEnricher) SongEnrich(ctx context.Context, groupName string, songName string, songDetail models.SongDetail) error {
+	ret := _m.Called(ctx, groupName, songName, songDetail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SongEnrich")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.SongDetail) error); ok {
+		r0 = rf(ctx, groupName, songName, songDetail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSongEnricher creates a new instance of SongEnricher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSongEnricher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SongEnricher {
+	mock := &SongEnricher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
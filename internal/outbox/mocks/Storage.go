@@ -0,0 +1,125 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "song-library/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// OutboxClaim provides a mock function with given fields: ctx, limit, lockFor
+func (_m *Storage) OutboxClaim(ctx context.Context, limit int, lockFor time.Duration) ([]models.OutboxEvent, error) {
+	ret := _m.Called(ctx, limit, lockFor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutboxClaim")
+	}
+
+	var r0 []models.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]models.OutboxEvent, error)); ok {
+		return rf(ctx, limit, lockFor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []models.OutboxEvent); ok {
+		r0 = rf(ctx, limit, lockFor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lockFor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxPrune provides a mock function with given fields: ctx, publishedBefore
+func (_m *Storage) OutboxPrune(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, publishedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutboxPrune")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, publishedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, publishedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, publishedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxPublished provides a mock function with given fields: ctx, eventID
+func (_m *Storage) OutboxPublished(ctx context.Context, eventID string) error {
+	ret := _m.Called(ctx, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutboxPublished")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRetry provides a mock function with given fields: ctx, eventID, lastError, nextAttemptAt
+func (_m *Storage) OutboxRetry(ctx context.Context, eventID string, lastError string, nextAttemptAt time.Time) error {
+	ret := _m.Called(ctx, eventID, lastError, nextAttemptAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutboxRetry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, eventID, lastError, nextAttemptAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Storage {
+	mock := &Storage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,257 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"song-library/internal/backoff"
+	"song-library/internal/models"
+	"time"
+)
+
+// pruneInterval is how often the events published before the retention are deleted
+const pruneInterval = time.Hour
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Storage
+type Storage interface {
+	OutboxClaim(ctx context.Context, limit int, lockFor time.Duration) ([]models.OutboxEvent, error)
+	OutboxPublished(ctx context.Context, eventID string) error
+	OutboxRetry(ctx context.Context, eventID string, lastError string, nextAttemptAt time.Time) error
+	OutboxPrune(ctx context.Context, publishedBefore time.Time) (deleted int64, err error)
+}
+
+// Sink publishes outbox events. An event may be published more than once,
+// e.g. if the relay crashes before it marks the event published, so consumers
+// skip the event ids they have already processed.
+//
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Sink
+type Sink interface {
+	Publish(ctx context.Context, event models.OutboxEvent) error
+}
+
+// SinkFunc is a function used as a Sink.
+type SinkFunc func(ctx context.Context, event models.OutboxEvent) error
+
+func (f SinkFunc) Publish(ctx context.Context, event models.OutboxEvent) error {
+	return f(ctx, event)
+}
+
+// Sinks publishes every event to all of the sinks in order. A failed event is published
+// again to all of them, the sinks that accepted it skip it by its id.
+type Sinks []Sink
+
+func (s Sinks) Publish(ctx context.Context, event models.OutboxEvent) error {
+	for _, sink := range s {
+		if err := sink.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the sinks that are io.Closer.
+func (s Sinks) Close() error {
+	var errs []error
+
+	for _, sink := range s {
+		if closer, ok := sink.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Options of the relay.
+type Options struct {
+	// PollInterval is how often the relay looks for due events
+	PollInterval time.Duration
+	// BatchSize is the number of events claimed at once
+	BatchSize int
+	// Timeout of publishing an event
+	Timeout time.Duration
+	// RetryBackoff is the delay before the second attempt, it doubles after each failed attempt
+	RetryBackoff time.Duration
+	// RetryMaxBackoff limits the delay between attempts
+	RetryMaxBackoff time.Duration
+	// Retention is how long published events are kept
+	Retention time.Duration
+}
+
+// Relay publishes the events of the outbox to the sink. Events are added to the outbox
+// in the transactions of the song changes, an event is marked published only after
+// the sink accepts it, so every committed change is published at least once.
+// Relays of several server replicas share the outbox, each event is claimed by one of them.
+type Relay struct {
+	storage         Storage
+	sink            Sink
+	log             *slog.Logger
+	pollInterval    time.Duration
+	batchSize       int
+	timeout         time.Duration
+	retryBackoff    time.Duration
+	retryMaxBackoff time.Duration
+	retention       time.Duration
+	now             func() time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New returns the relay of the events to the sink, a nil sink disables the relay.
+func New(opts Options, storage Storage, sink Sink, log *slog.Logger) *Relay {
+	return &Relay{
+		storage:         storage,
+		sink:            sink,
+		log:             log.With(slog.String("op", "outbox")),
+		pollInterval:    opts.PollInterval,
+		batchSize:       opts.BatchSize,
+		timeout:         opts.Timeout,
+		retryBackoff:    opts.RetryBackoff,
+		retryMaxBackoff: opts.RetryMaxBackoff,
+		retention:       opts.Retention,
+		now:             time.Now,
+	}
+}
+
+// Start publishes the due events every poll interval until Stop.
+func (r *Relay) Start() {
+	if r.sink == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	r.log.Info("Starting outbox relay", slog.Duration("poll_interval", r.pollInterval))
+
+	go func() {
+		defer close(r.done)
+
+		prune := time.NewTicker(pruneInterval)
+		defer prune.Stop()
+
+		r.prune(ctx)
+
+		for {
+			published, err := r.PublishDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.log.Error("Failed to publish outbox events", slog.Any("error", err))
+			}
+
+			// A full batch means more events may be due
+			if err == nil && published == r.batchSize {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-prune.C:
+				r.prune(ctx)
+			case <-time.After(r.pollInterval):
+			}
+		}
+	}()
+}
+
+// Stop stops the relay and closes the sink. The events being published are
+// cancelled and published again after the restart.
+func (r *Relay) Stop() {
+	if r.cancel == nil {
+		return
+	}
+
+	r.cancel()
+	<-r.done
+
+	if closer, ok := r.sink.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			r.log.Error("Failed to close outbox sink", slog.Any("error", err))
+		}
+	}
+}
+
+// PublishDue publishes a batch of the due events in the order they were added
+// and returns the number of processed events, failed events are retried with backoff.
+func (r *Relay) PublishDue(ctx context.Context) (processed int, err error) {
+	// Events are published one by one, the lock covers the whole batch
+	events, err := r.storage.OutboxClaim(ctx, r.batchSize, time.Duration(r.batchSize)*r.timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, event := range events {
+		if ctx.Err() != nil {
+			// The claimed events are released, so they do not wait for the lock to expire
+			for _, event := range events[i:] {
+				r.retry(context.WithoutCancel(ctx), event, ctx.Err().Error(), r.now())
+			}
+
+			return processed, ctx.Err()
+		}
+
+		r.publish(ctx, event)
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (r *Relay) publish(ctx context.Context, event models.OutboxEvent) {
+	log := r.log.With(
+		slog.String("event_id", event.ID),
+		slog.String("event", event.Event),
+		slog.Int("attempt", event.Attempts))
+
+	publishCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	err := r.sink.Publish(publishCtx, event)
+	cancel()
+
+	// The result is recorded even if the relay is stopping
+	ctx = context.WithoutCancel(ctx)
+
+	if err != nil {
+		retryIn := backoff.Exponential(r.retryBackoff, r.retryMaxBackoff, event.Attempts)
+
+		log.WarnContext(ctx, "Failed to publish outbox event, retrying",
+			slog.Duration("retry_in", retryIn),
+			slog.Any("error", err))
+
+		r.retry(ctx, event, err.Error(), r.now().Add(retryIn))
+
+		return
+	}
+
+	log.DebugContext(ctx, "Outbox event published")
+
+	if err = r.storage.OutboxPublished(ctx, event.ID); err != nil {
+		// The event is published again when its lock expires
+		log.ErrorContext(ctx, "Failed to mark outbox event published", slog.Any("error", err))
+	}
+}
+
+func (r *Relay) retry(ctx context.Context, event models.OutboxEvent, lastError string, nextAttemptAt time.Time) {
+	if err := r.storage.OutboxRetry(ctx, event.ID, lastError, nextAttemptAt); err != nil {
+		r.log.ErrorContext(ctx, "Failed to record outbox event attempt",
+			slog.String("event_id", event.ID),
+			slog.Any("error", err))
+	}
+}
+
+func (r *Relay) prune(ctx context.Context) {
+	if r.retention <= 0 {
+		return
+	}
+
+	deleted, err := r.storage.OutboxPrune(ctx, r.now().Add(-r.retention))
+	switch {
+	case err != nil && ctx.Err() == nil:
+		r.log.Error("Failed to prune outbox", slog.Any("error", err))
+	case deleted > 0:
+		r.log.Info("Pruned outbox", slog.Int64("deleted", deleted))
+	}
+}
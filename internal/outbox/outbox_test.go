@@ -0,0 +1,191 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/outbox/mocks"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func outboxEvent(id string, attempts int) models.OutboxEvent {
+	return models.OutboxEvent{
+		ID:         id,
+		Event:      models.EventSongCreated,
+		OccurredAt: now,
+		Song:       models.Song{GroupName: "Muse", SongName: "Uprising"},
+		Attempts:   attempts,
+	}
+}
+
+func newTestRelay(storage Storage, sink Sink) *Relay {
+	relay := New(Options{
+		BatchSize:       10,
+		Timeout:         time.Second,
+		RetryBackoff:    time.Second,
+		RetryMaxBackoff: 10 * time.Second,
+	}, storage, sink, slogdiscard.NewDiscardLogger())
+	relay.now = func() time.Time { return now }
+
+	return relay
+}
+
+func TestPublishDue(t *testing.T) {
+	published := outboxEvent("5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a", 1)
+	failed := outboxEvent("7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", 3)
+
+	storageMock := mocks.NewStorage(t)
+	storageMock.On("OutboxClaim", mock.Anything, 10, 10*time.Second).
+		Return([]models.OutboxEvent{published, failed}, nil).Once()
+	storageMock.On("OutboxPublished", mock.Anything, published.ID).Return(nil).Once()
+	// The backoff doubles after each failed attempt
+	storageMock.On("OutboxRetry", mock.Anything, failed.ID, "sink is down", now.Add(4*time.Second)).
+		Return(nil).Once()
+
+	sinkMock := mocks.NewSink(t)
+	sinkMock.On("Publish", mock.Anything, published).Return(nil).Once()
+	sinkMock.On("Publish", mock.Anything, failed).Return(errors.New("sink is down")).Once()
+
+	processed, err := newTestRelay(storageMock, sinkMock).PublishDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, processed)
+}
+
+func TestPublishDueReleasesEventsOnStop(t *testing.T) {
+	event := outboxEvent("5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a", 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	storageMock := mocks.NewStorage(t)
+	storageMock.On("OutboxClaim", mock.Anything, 10, 10*time.Second).
+		Return([]models.OutboxEvent{event}, nil).Once()
+	storageMock.On("OutboxRetry", mock.Anything, event.ID, context.Canceled.Error(), now).Return(nil).Once()
+
+	processed, err := newTestRelay(storageMock, mocks.NewSink(t)).PublishDue(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Zero(t, processed)
+}
+
+func TestSinks(t *testing.T) {
+	event := outboxEvent("5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a", 1)
+
+	var webhooks []models.OutboxEvent
+
+	sinkMock := mocks.NewSink(t)
+	sinkMock.On("Publish", mock.Anything, event).Return(nil).Once()
+	sinkMock.On("Publish", mock.Anything, event).Return(errors.New("sink is down")).Once()
+
+	sinks := Sinks{
+		SinkFunc(func(ctx context.Context, event models.OutboxEvent) error {
+			webhooks = append(webhooks, event)
+			return nil
+		}),
+		sinkMock,
+	}
+
+	require.NoError(t, sinks.Publish(context.Background(), event))
+	require.EqualError(t, sinks.Publish(context.Background(), event), "sink is down")
+	// Every sink gets the event again, the ones that accepted it skip it by its id
+	require.Equal(t, []models.OutboxEvent{event, event}, webhooks)
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+
+	first := outboxEvent("5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a", 1)
+	second := outboxEvent("7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", 1)
+
+	require.NoError(t, sink.Publish(context.Background(), first))
+	require.NoError(t, sink.Publish(context.Background(), second))
+	require.NoError(t, sink.Close())
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = file.Close() })
+
+	var lines []string
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		lines = append(lines, scanner.Text())
+	}
+
+	require.Equal(t, []string{
+		`{"id":"5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a","event":"song.created",` +
+			`"occurredAt":"2024-10-01T12:00:00Z","song":{"group":"Muse","song":"Uprising"}}`,
+		`{"id":"7a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d","event":"song.created",` +
+			`"occurredAt":"2024-10-01T12:00:00Z","song":{"group":"Muse","song":"Uprising"}}`,
+	}, lines)
+}
+
+func TestWriterSink(t *testing.T) {
+	var out strings.Builder
+
+	err := NewWriterSink(&out).Publish(context.Background(), outboxEvent("5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a", 1))
+	require.NoError(t, err)
+	require.True(t, strings.HasSuffix(out.String(), "}\n"))
+	require.Equal(t, 1, strings.Count(out.String(), "\n"))
+}
+
+func TestHTTPSink(t *testing.T) {
+	cases := []struct {
+		name         string
+		responseCode int
+		err          bool
+	}{
+		{
+			name:         "Accepted",
+			responseCode: http.StatusAccepted,
+		},
+		{
+			name:         "Server error",
+			responseCode: http.StatusServiceUnavailable,
+			err:          true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := outboxEvent("5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a", 1)
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.Equal(t, event.ID, r.Header.Get("Idempotency-Key"))
+				require.Equal(t, models.EventSongCreated, r.Header.Get(HeaderEvent))
+
+				var received models.OutboxEvent
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+				event.Attempts = 0
+				require.Equal(t, event, received)
+
+				w.WriteHeader(tc.responseCode)
+			}))
+			t.Cleanup(receiver.Close)
+
+			err := NewHTTPSink(receiver.URL, receiver.Client()).Publish(context.Background(), event)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"song-library/internal/config"
+	"song-library/internal/models"
+	"sync"
+)
+
+// HeaderEvent is the header of the event name in the requests of HTTPSink.
+// The event id is sent in the Idempotency-Key header.
+const HeaderEvent = "X-Outbox-Event"
+
+const userAgent = "song-library-outbox/1.0"
+
+// NewSink returns the sink set by OUTBOX_SINK, nil if it is not set.
+func NewSink(cfg *config.Config) (Sink, error) {
+	switch cfg.OutboxSink {
+	case "":
+		return nil, nil
+	case "stdout":
+		return NewWriterSink(os.Stdout), nil
+	case "file":
+		sink, err := NewFileSink(cfg.OutboxFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return sink, nil
+	case "http":
+		return NewHTTPSink(cfg.OutboxURL, &http.Client{Timeout: cfg.OutboxTimeout}), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox sink %q", cfg.OutboxSink)
+	}
+}
+
+// WriterSink writes every event as a line of JSON.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Publish(_ context.Context, event models.OutboxEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(line, '\n'))
+
+	return err
+}
+
+// FileSink appends every event to the file as a line of JSON,
+// an event is published when it is synced to the disk.
+type FileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox file: %w", err)
+	}
+
+	return &FileSink{file: file}, nil
+}
+
+func (s *FileSink) Publish(_ context.Context, event models.OutboxEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err = s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return s.file.Sync()
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+// HTTPSink POSTs every event to the URL as JSON, responses other than 2xx fail the attempt.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, client *http.Client) *HTTPSink {
+	return &HTTPSink{url: url, client: client}
+}
+
+func (s *HTTPSink) Publish(ctx context.Context, event models.OutboxEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Idempotency-Key", event.ID)
+	req.Header.Set(HeaderEvent, event.Event)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("outbox sink responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package songtext
+
+import (
+	"errors"
+	"strings"
+)
+
+// Pagination modes of the song text
+const (
+	ByVerse = "verse"
+	ByLine  = "line"
+	ByChars = "chars"
+)
+
+// Default page sizes for each pagination mode
+const (
+	defaultVerseSize = 1
+	defaultLineSize  = 4
+	defaultCharsSize = 500
+)
+
+var ErrUnknownPaginationMode = errors.New("unknown pagination mode")
+
+// DefaultPageSize returns the page size used when the 'size' parameter is omitted.
+func DefaultPageSize(by string) int {
+	switch by {
+	case ByLine:
+		return defaultLineSize
+	case ByChars:
+		return defaultCharsSize
+	default:
+		return defaultVerseSize
+	}
+}
+
+// normalizeText converts Windows and old Mac line endings to '\n'
+// and trims blank lines at the beginning and the end of the text.
+func normalizeText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	return strings.Trim(text, "\n")
+}
+
+// splitVerses splits the song text into verses.
+// Verses are separated by one or more blank lines, a line of spaces or tabs is blank too.
+func splitVerses(text string) []string {
+	verses := make([]string, 0)
+	verse := make([]string, 0)
+
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			verse = append(verse, line)
+			continue
+		}
+
+		if len(verse) > 0 {
+			verses = append(verses, strings.Join(verse, "\n"))
+			verse = verse[:0]
+		}
+	}
+
+	if len(verse) > 0 {
+		verses = append(verses, strings.Join(verse, "\n"))
+	}
+
+	return verses
+}
+
+// verseKey returns the key used to compare verses.
+// Letter case and extra spaces are ignored, so a chorus
+// is still detected if it was typed slightly differently.
+func verseKey(verse string) string {
+	return strings.ToLower(strings.Join(strings.Fields(verse), " "))
+}
+
+// collapseRepeats removes repeated verses (choruses), only the first occurrence is kept.
+func collapseRepeats(verses []string) []string {
+	seen := make(map[string]struct{}, len(verses))
+	unique := make([]string, 0, len(verses))
+
+	for _, verse := range verses {
+		key := verseKey(verse)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+		unique = append(unique, verse)
+	}
+
+	return unique
+}
+
+// Paginate splits the song text into pages.
+//
+//	by = "verse" - each page contains 'size' verses
+//	by = "line"  - each page contains 'size' lines, blank lines are skipped
+//	by = "chars" - each page contains at most 'size' characters, words are not broken
+//
+// If unique is true, repeated verses (choruses) are collapsed before pagination.
+func Paginate(text string, by string, size int, unique bool) ([]string, error) {
+	verses := splitVerses(normalizeText(text))
+
+	if unique {
+		verses = collapseRepeats(verses)
+	}
+
+	switch by {
+	case ByVerse:
+		return joinChunks(verses, size, "\n\n"), nil
+	case ByLine:
+		lines := make([]string, 0)
+		for _, verse := range verses {
+			for _, line := range strings.Split(verse, "\n") {
+				if strings.TrimSpace(line) != "" {
+					lines = append(lines, line)
+				}
+			}
+		}
+		return joinChunks(lines, size, "\n"), nil
+	case ByChars:
+		return splitChars(strings.Join(verses, "\n\n"), size), nil
+	default:
+		return nil, ErrUnknownPaginationMode
+	}
+}
+
+// joinChunks groups items by 'size' and joins every group with the separator.
+func joinChunks(items []string, size int, separator string) []string {
+	pages := make([]string, 0, (len(items)+size-1)/size)
+
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		pages = append(pages, strings.Join(items[start:end], separator))
+	}
+
+	return pages
+}
+
+// splitChars splits the text into pages of at most 'size' characters.
+// Pages are broken at the last whitespace before the limit,
+// a word longer than the page size is broken at the limit.
+func splitChars(text string, size int) []string {
+	pages := make([]string, 0)
+	runes := []rune(text)
+
+	for len(runes) > 0 {
+		if len(runes) <= size {
+			pages = append(pages, string(runes))
+			break
+		}
+
+		end := size
+		for i := size; i > 0; i-- {
+			if runes[i] == ' ' || runes[i] == '\n' {
+				end = i
+				break
+			}
+		}
+
+		page := strings.TrimRight(string(runes[:end]), " \n")
+		if page != "" {
+			pages = append(pages, page)
+		}
+
+		runes = []rune(strings.TrimLeft(string(runes[end:]), " \n"))
+	}
+
+	return pages
+}
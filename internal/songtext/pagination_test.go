@@ -0,0 +1,109 @@
+package songtext
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	const song = "Verse one\nline two\n\nChorus\nla la\n\nVerse three\nline four\n\nchorus\n  la   la"
+
+	cases := []struct {
+		name   string
+		text   string
+		by     string
+		size   int
+		unique bool
+		pages  []string
+	}{
+		{
+			name:  "By verse",
+			text:  song,
+			by:    ByVerse,
+			size:  1,
+			pages: []string{"Verse one\nline two", "Chorus\nla la", "Verse three\nline four", "chorus\n  la   la"},
+		},
+		{
+			name:  "By two verses",
+			text:  song,
+			by:    ByVerse,
+			size:  2,
+			pages: []string{"Verse one\nline two\n\nChorus\nla la", "Verse three\nline four\n\nchorus\n  la   la"},
+		},
+		{
+			name:   "By verse, unique",
+			text:   song,
+			by:     ByVerse,
+			size:   1,
+			unique: true,
+			pages:  []string{"Verse one\nline two", "Chorus\nla la", "Verse three\nline four"},
+		},
+		{
+			name:  "By line",
+			text:  song,
+			by:    ByLine,
+			size:  3,
+			pages: []string{"Verse one\nline two\nChorus", "la la\nVerse three\nline four", "chorus\n  la   la"},
+		},
+		{
+			name:  "By chars",
+			text:  "one two three four",
+			by:    ByChars,
+			size:  8,
+			pages: []string{"one two", "three", "four"},
+		},
+		{
+			name:  "By chars, long word",
+			text:  "abcdefghij",
+			by:    ByChars,
+			size:  4,
+			pages: []string{"abcd", "efgh", "ij"},
+		},
+		{
+			name:  "Windows line endings",
+			text:  "first\r\nverse\r\n\r\nsecond verse\r\n",
+			by:    ByVerse,
+			size:  1,
+			pages: []string{"first\nverse", "second verse"},
+		},
+		{
+			name:  "Extra blank lines",
+			text:  "\n\nfirst\n\n\n\nsecond\n\n",
+			by:    ByVerse,
+			size:  1,
+			pages: []string{"first", "second"},
+		},
+		{
+			name:  "Whitespace-only separator lines",
+			text:  "first\nverse\n  \t\nsecond verse\n \n\n third",
+			by:    ByVerse,
+			size:  1,
+			pages: []string{"first\nverse", "second verse", " third"},
+		},
+		{
+			name:  "Empty text",
+			text:  "",
+			by:    ByVerse,
+			size:  1,
+			pages: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pages, err := Paginate(tc.text, tc.by, tc.size, tc.unique)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.pages, pages)
+		})
+	}
+}
+
+func TestPaginate_UnknownMode(t *testing.T) {
+	_, err := Paginate("text", "word", 1, false)
+	require.ErrorIs(t, err, ErrUnknownPaginationMode)
+}
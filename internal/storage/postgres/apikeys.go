@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+)
+
+func (s *Storage) APIKeyCreate(ctx context.Context, name string, keyHash string) (keyID int, err error) {
+	const op = "storage.postgres.APIKeyCreate"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `INSERT INTO api_keys (name, key_hash)
+				VALUES ($1, $2)
+				RETURNING id`
+
+	err = s.db.QueryRowContext(ctx, sqlStr, name, keyHash).Scan(&keyID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to add api key: %w", op, err)
+	}
+
+	return keyID, nil
+}
+
+func (s *Storage) APIKeyRevoke(ctx context.Context, keyID int) (err error) {
+	const op = "storage.postgres.APIKeyRevoke"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE api_keys
+			SET revoked_at = now()
+			WHERE id = ($1) AND revoked_at IS NULL`
+
+	result, err := s.db.ExecContext(ctx, sqlStr, keyID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to revoke api key: %w", op, err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// APIKeyFind finds the active (not revoked) API key by its hash.
+func (s *Storage) APIKeyFind(ctx context.Context, keyHash string) (apiKey models.APIKey, err error) {
+	const op = "storage.postgres.APIKeyFind"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT id, name, created_at
+			FROM api_keys
+			WHERE key_hash = ($1) AND revoked_at IS NULL`
+
+	err = s.db.QueryRowContext(ctx, sqlStr, keyHash).
+		Scan(&apiKey.ID, &apiKey.Name, &apiKey.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.APIKey{}, storage.ErrAPIKeyNotFound
+		}
+
+		return models.APIKey{}, fmt.Errorf("%s: failed to find api key: %w", op, err)
+	}
+
+	return apiKey, nil
+}
+
+func (s *Storage) APIKeys(ctx context.Context) (apiKeys []models.APIKey, err error) {
+	const op = "storage.postgres.APIKeys"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT id, name, created_at, revoked_at
+			FROM api_keys
+			ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query api keys: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var apiKey models.APIKey
+		var revokedAt sql.NullTime
+
+		err = rows.Scan(&apiKey.ID, &apiKey.Name, &apiKey.CreatedAt, &revokedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to query api keys: %w", op, err)
+		}
+
+		if revokedAt.Valid {
+			apiKey.RevokedAt = &revokedAt.Time
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query api keys: %w", op, err)
+	}
+
+	return apiKeys, nil
+}
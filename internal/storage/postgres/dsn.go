@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"net"
+	"net/url"
+	"song-library/internal/config"
+	"strings"
+)
+
+// dsn returns the connection string of the database.
+// DATABASE_URL overrides the separate PG_* connection settings.
+func dsn(cfg *config.Config) string {
+	if cfg.DatabaseURL != "" {
+		return cfg.DatabaseURL
+	}
+
+	queryParameters := url.Values{}
+	queryParameters.Add("sslmode", cfg.PgSSLMode)
+
+	if cfg.PgSSLRootCert != "" {
+		queryParameters.Add("sslrootcert", cfg.PgSSLRootCert)
+	}
+
+	if cfg.PgSSLCert != "" {
+		queryParameters.Add("sslcert", cfg.PgSSLCert)
+	}
+
+	if cfg.PgSSLKey != "" {
+		queryParameters.Add("sslkey", cfg.PgSSLKey)
+	}
+
+	postgresURL := url.URL{
+		Scheme:   "postgres",
+		Host:     net.JoinHostPort(cfg.PgHost, cfg.PgPort),
+		User:     url.UserPassword(cfg.PgUser, cfg.PgPass),
+		Path:     cfg.PgDatabase,
+		RawQuery: queryParameters.Encode(),
+	}
+
+	return postgresURL.String()
+}
+
+// databaseName returns the name of the database the storage connects to.
+func databaseName(cfg *config.Config) string {
+	if cfg.DatabaseURL == "" {
+		return cfg.PgDatabase
+	}
+
+	databaseURL, err := url.Parse(cfg.DatabaseURL)
+	if err != nil || (databaseURL.Scheme != "postgres" && databaseURL.Scheme != "postgresql") {
+		return cfg.PgDatabase
+	}
+
+	return strings.TrimPrefix(databaseURL.Path, "/")
+}
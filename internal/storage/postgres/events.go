@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/lib/pq"
+	"log/slog"
+	"song-library/internal/models"
+	"time"
+)
+
+// songEventsChannel is notified by the song_events_notify trigger, the payload is models.SongEvent
+const songEventsChannel = "song_events"
+
+// SongEventsSince returns up to limit events logged after the event afterID, the oldest first.
+func (s *Storage) SongEventsSince(ctx context.Context, afterID int64, limit int) (events []models.SongEvent, err error) {
+	const op = "storage.postgres.SongEventsSince"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT id, event, group_name, song_name, created_at
+			FROM song_events
+			WHERE id > ($1)
+			ORDER BY id
+			LIMIT ($2)`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query song events: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var event models.SongEvent
+
+		err = rows.Scan(&event.ID, &event.Event, &event.Song.GroupName, &event.Song.SongName, &event.OccurredAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to query song events: %w", op, err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query song events: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// SongEventsPrune deletes the events logged before the time and returns their number.
+func (s *Storage) SongEventsPrune(ctx context.Context, before time.Time) (deleted int64, err error) {
+	const op = "storage.postgres.SongEventsPrune"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM song_events WHERE created_at < ($1)`, before)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to delete song events: %w", op, err)
+	}
+
+	deleted, _ = result.RowsAffected()
+
+	return deleted, nil
+}
+
+// ListenSongEvents calls notify for every song event committed by any server until ctx is done.
+// The listener has its own connection, it is reconnected when it is lost. Notifications sent
+// before listening or while the connection was down are lost, so listening is called when
+// the listening starts or resumes to let the caller catch up from the log.
+func (s *Storage) ListenSongEvents(ctx context.Context, log *slog.Logger, notify func(models.SongEvent),
+	listening func()) error {
+	const (
+		op                = "storage.postgres.ListenSongEvents"
+		minReconnectDelay = 250 * time.Millisecond
+		maxReconnectDelay = 10 * time.Second
+		pingInterval      = 90 * time.Second
+	)
+
+	log = log.With(slog.String("op", op))
+
+	listener := pq.NewListener(s.dsn, minReconnectDelay, maxReconnectDelay,
+		func(event pq.ListenerEventType, err error) {
+			switch event {
+			case pq.ListenerEventDisconnected:
+				log.Warn("Lost connection of song events listener", slog.Any("error", err))
+			case pq.ListenerEventConnectionAttemptFailed:
+				log.Warn("Failed to connect song events listener", slog.Any("error", err))
+			case pq.ListenerEventReconnected:
+				log.Info("Song events listener reconnected")
+			}
+		})
+
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	if err := listener.Listen(songEventsChannel); err != nil {
+		return fmt.Errorf("%s: failed to listen: %w", op, err)
+	}
+
+	listening()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// nil is sent after the connection is re-established
+			if notification == nil {
+				listening()
+				continue
+			}
+
+			var event models.SongEvent
+			if err := json.Unmarshal([]byte(notification.Extra), &event); err != nil {
+				log.Error("Failed to decode song event", slog.Any("error", err))
+				continue
+			}
+
+			notify(event)
+		case <-ticker.C:
+			// Detects a broken connection if no notifications are sent for a long time
+			go func() {
+				_ = listener.Ping()
+			}()
+		}
+	}
+}
+
+// LastSongEventID returns the id of the latest logged event, zero if the log is empty.
+func (s *Storage) LastSongEventID(ctx context.Context) (eventID int64, err error) {
+	const op = "storage.postgres.LastSongEventID"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err = s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(id), 0) FROM song_events`).Scan(&eventID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to get last song event: %w", op, err)
+	}
+
+	return eventID, nil
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/lib/pq"
+	"song-library/internal/models"
+)
+
+// Groups returns a page of the groups ordered by name, only the group with the name if it is set.
+func (s *Storage) Groups(ctx context.Context, name string, page int, limit int) (groups []models.Group, err error) {
+	const op = "storage.postgres.Groups"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT DISTINCT name
+			FROM groups
+			WHERE ($1) = '' OR name = ($1)
+			ORDER BY name
+			OFFSET ($2)
+			LIMIT ($3)`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr, name, (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query groups: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var group models.Group
+
+		if err = rows.Scan(&group.Name); err != nil {
+			return nil, fmt.Errorf("%s: failed to query groups: %w", op, err)
+		}
+
+		groups = append(groups, group)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query groups: %w", op, err)
+	}
+
+	return groups, nil
+}
+
+// SongsByGroups returns up to limit songs of every group in one query, keyed by the group name.
+// Groups without songs are missing from the result.
+func (s *Storage) SongsByGroups(ctx context.Context, groupNames []string,
+	limit int) (songs map[string][]models.SongWithDetail, err error) {
+	const op = "storage.postgres.SongsByGroups"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT	group_name,
+					name,
+					release_date,
+					text,
+					link,
+					link_status,
+					link_status_code,
+					link_redirect_to,
+					link_checked_at
+			FROM (
+				SELECT	g.name AS group_name,
+						s.*,
+						ROW_NUMBER() OVER (PARTITION BY g.name ORDER BY s.id) AS n
+				FROM songs s
+				JOIN groups g ON s.group_id = g.id
+				WHERE g.name = ANY($1)
+			) numbered
+			WHERE n <= ($2)
+			ORDER BY group_name, n`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr, pq.Array(groupNames), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query songs: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	songs = make(map[string][]models.SongWithDetail, len(groupNames))
+
+	for rows.Next() {
+		var song models.SongWithDetail
+
+		song, err = scanSongWithDetail(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to query songs: %w", op, err)
+		}
+
+		songs[song.GroupName] = append(songs[song.GroupName], song)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query songs: %w", op, err)
+	}
+
+	return songs, nil
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"time"
+)
+
+const jobColumns = `id, kind, payload, status, attempts, max_attempts, last_error, run_at, created_at, updated_at`
+
+func scanJob(row interface{ Scan(dest ...any) error }) (job models.Job, err error) {
+	var lastError sql.NullString
+
+	err = row.Scan(&job.ID, &job.Kind, &job.Payload, &job.Status, &job.Attempts, &job.MaxAttempts,
+		&lastError, &job.RunAt, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	job.LastError = lastError.String
+
+	return job, nil
+}
+
+func (s *Storage) EnqueueJob(ctx context.Context, kind string, payload []byte, maxAttempts int) (jobID int64, err error) {
+	const op = "storage.postgres.EnqueueJob"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `INSERT INTO jobs (kind, payload, max_attempts)
+				VALUES ($1, $2, $3)
+				RETURNING id`
+
+	err = s.db.QueryRowContext(ctx, sqlStr, kind, payload, maxAttempts).Scan(&jobID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to add job: %w", op, err)
+	}
+
+	return jobID, nil
+}
+
+// ClaimJob locks the next job of the kinds that is ready to run for lockFor
+// and counts the attempt. Pending jobs are ready at run_at, running jobs when their
+// lock expires, i.e. the worker running them died. Jobs locked by other workers
+// are skipped, so workers never wait for each other. ErrNoJobs is returned if no job is ready.
+func (s *Storage) ClaimJob(ctx context.Context, kinds []string, lockFor time.Duration) (job models.Job, err error) {
+	const op = "storage.postgres.ClaimJob"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE jobs
+			SET status = 'running',
+			    attempts = attempts + 1,
+			    locked_until = now() + $2 * interval '1 millisecond',
+			    updated_at = now()
+			WHERE id = (
+				SELECT id
+				FROM jobs
+				WHERE kind = ANY($1)
+				  AND ((status = 'pending' AND run_at <= now())
+				    OR (status = 'running' AND locked_until < now()))
+				ORDER BY run_at, id
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED)
+			RETURNING ` + jobColumns
+
+	job, err = scanJob(s.db.QueryRowContext(ctx, sqlStr, pq.Array(kinds), lockFor.Milliseconds()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Job{}, storage.ErrNoJobs
+		}
+
+		return models.Job{}, fmt.Errorf("%s: failed to claim job: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// CompleteJob marks the job done. The job must still be running the attempt,
+// otherwise ErrJobLost is returned.
+func (s *Storage) CompleteJob(ctx context.Context, jobID int64, attempt int) (err error) {
+	const op = "storage.postgres.CompleteJob"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE jobs
+			SET status = 'done', last_error = NULL, locked_until = NULL, updated_at = now()
+			WHERE id = $1 AND status = 'running' AND attempts = $2`
+
+	return s.updateJob(ctx, op, sqlStr, jobID, attempt)
+}
+
+// RetryJob records the error of the failed attempt and schedules the job to run again at runAt.
+// The job must still be running the attempt, otherwise ErrJobLost is returned.
+func (s *Storage) RetryJob(ctx context.Context, jobID int64, attempt int, jobErr string, runAt time.Time) (err error) {
+	const op = "storage.postgres.RetryJob"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE jobs
+			SET status = 'pending', last_error = $3, run_at = $4, locked_until = NULL, updated_at = now()
+			WHERE id = $1 AND status = 'running' AND attempts = $2`
+
+	return s.updateJob(ctx, op, sqlStr, jobID, attempt, jobErr, runAt)
+}
+
+// DeadJob records the error of the failed attempt and gives up the job,
+// dead jobs are kept for inspection and are not run again.
+// The job must still be running the attempt, otherwise ErrJobLost is returned.
+func (s *Storage) DeadJob(ctx context.Context, jobID int64, attempt int, jobErr string) (err error) {
+	const op = "storage.postgres.DeadJob"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE jobs
+			SET status = 'dead', last_error = $3, locked_until = NULL, updated_at = now()
+			WHERE id = $1 AND status = 'running' AND attempts = $2`
+
+	return s.updateJob(ctx, op, sqlStr, jobID, attempt, jobErr)
+}
+
+// updateJob records the result of the attempt. No updated rows mean the lock of the attempt
+// expired and the job was claimed again, so its result belongs to the new attempt.
+func (s *Storage) updateJob(ctx context.Context, op string, sqlStr string, args ...any) error {
+	result, err := s.db.ExecContext(ctx, sqlStr, args...)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update job: %w", op, err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrJobLost
+	}
+
+	return nil
+}
+
+func (s *Storage) Job(ctx context.Context, jobID int64) (job models.Job, err error) {
+	const op = "storage.postgres.Job"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	job, err = scanJob(s.db.QueryRowContext(ctx, sqlStr, jobID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Job{}, storage.ErrJobNotFound
+		}
+
+		return models.Job{}, fmt.Errorf("%s: failed to get job: %w", op, err)
+	}
+
+	return job, nil
+}
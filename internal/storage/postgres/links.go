@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"time"
+)
+
+// SongLinksToCheck returns up to limit links never checked or checked before checkedBefore,
+// the links not checked for the longest time first.
+func (s *Storage) SongLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) (links []models.SongLink, err error) {
+	const op = "storage.postgres.SongLinksToCheck"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT id, link
+			FROM songs
+			WHERE COALESCE(link, '') <> ''
+			  AND (link_checked_at IS NULL OR link_checked_at < ($1))
+			ORDER BY link_checked_at NULLS FIRST, id
+			LIMIT ($2)`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr, checkedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query song links: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var link models.SongLink
+
+		if err = rows.Scan(&link.SongID, &link.Link); err != nil {
+			return nil, fmt.Errorf("%s: failed to query song links: %w", op, err)
+		}
+
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query song links: %w", op, err)
+	}
+
+	return links, nil
+}
+
+// SongLinkCheckSave saves the result of the link check. ErrSongNotFound is returned
+// if the song is deleted or its link is changed since it was checked.
+func (s *Storage) SongLinkCheckSave(ctx context.Context, link models.SongLink, linkCheck models.LinkCheck) (err error) {
+	const op = "storage.postgres.SongLinkCheckSave"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE songs
+			SET link_status = ($3),
+			    link_status_code = ($4),
+			    link_redirect_to = ($5),
+			    link_checked_at = ($6)
+			WHERE id = ($1) AND link = ($2)`
+
+	result, err := s.db.ExecContext(ctx, sqlStr,
+		link.SongID,
+		link.Link,
+		linkCheck.Status,
+		sql.NullInt32{Int32: int32(linkCheck.StatusCode), Valid: linkCheck.StatusCode != 0},
+		sql.NullString{String: linkCheck.RedirectTo, Valid: linkCheck.RedirectTo != ""},
+		linkCheck.CheckedAt)
+	if err != nil {
+		return fmt.Errorf("%s: failed to save link check: %w", op, err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrSongNotFound
+	}
+
+	return nil
+}
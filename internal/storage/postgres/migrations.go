@@ -1,14 +1,68 @@
 package postgres
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/golang-migrate/migrate"
 	"github.com/golang-migrate/migrate/database/postgres"
-	_ "github.com/golang-migrate/migrate/source/file"
+	"github.com/golang-migrate/migrate/source"
+	bindata "github.com/golang-migrate/migrate/source/go_bindata"
 	_ "github.com/lib/pq"
+	"io/fs"
 	"log/slog"
+	"os"
+	"song-library/migrations"
 )
 
+// migrationsSource returns the source driver of the migrations embedded into the binary.
+func migrationsSource() (source.Driver, error) {
+	names, err := fs.Glob(migrations.FS, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	return bindata.WithInstance(bindata.Resource(names, migrations.FS.ReadFile))
+}
+
+// newMigrate returns the migrate instance of the embedded migrations and the storage database.
+// It has its own database connection, as closing the instance closes the database it was given.
+// The instance must be closed by closeMigrate.
+func (s *Storage) newMigrate() (*migrate.Migrate, error) {
+	sourceDriver, err := migrationsSource()
+	if err != nil {
+		return nil, fmt.Errorf("unable to open migrations: %w", err)
+	}
+
+	db, err := sql.Open("postgres", s.dsn)
+	if err != nil {
+		_ = sourceDriver.Close()
+		return nil, fmt.Errorf("unable to open database for migrations: %w", err)
+	}
+
+	databaseDriver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		_ = sourceDriver.Close()
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to create database driver for migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("embed", sourceDriver, "postgres", databaseDriver)
+	if err != nil {
+		_ = sourceDriver.Close()
+		_ = databaseDriver.Close()
+		return nil, fmt.Errorf("unable to create migrations: %w", err)
+	}
+
+	return m, nil
+}
+
+// closeMigrate closes the migrations and the database connection of the migrate instance.
+func closeMigrate(m *migrate.Migrate) {
+	_, _ = m.Close()
+}
+
 func (s *Storage) makeMigrations(log *slog.Logger) error {
 	const op = "storage.postgres.migrations"
 
@@ -16,34 +70,150 @@ func (s *Storage) makeMigrations(log *slog.Logger) error {
 
 	log.Debug("Start migrations")
 
-	driver, err := postgres.WithInstance(s.db, &postgres.Config{})
-	if err != nil {
-		log.Error("Unable to create database driver for migrations", slog.Any("error", err))
+	if err := s.MigrateUp(0); err != nil {
+		log.Error("Unable to run migrations", slog.Any("error", err))
 		return err
 	}
 
-	migrationsPath := "file://./migrations"
+	log.Debug("Migrations successfully completed")
+
+	return nil
+}
+
+// MigrateUp applies 'steps' migrations, or all pending migrations if steps is 0.
+func (s *Storage) MigrateUp(steps int) error {
+	const op = "storage.postgres.MigrateUp"
 
-	migrations, err := migrate.NewWithDatabaseInstance(
-		migrationsPath,
-		"postgres", driver)
+	m, err := s.newMigrate()
 	if err != nil {
-		log.Error("Unable to create migrate instance",
-			slog.String("migrations path", migrationsPath),
-			slog.Any("error", err))
-		return err
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := migrations.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			log.Debug("No migrations to run")
-		} else {
-			log.Error("Unable to run migrations", slog.Any("error", err))
-			return err
+	defer closeMigrate(m)
+
+	if steps > 0 {
+		err = m.Steps(steps)
+	} else {
+		err = m.Up()
+	}
+
+	return migrateResult(op, err)
+}
+
+// MigrateDown rolls back 'steps' last applied migrations.
+func (s *Storage) MigrateDown(steps int) error {
+	const op = "storage.postgres.MigrateDown"
+
+	if steps <= 0 {
+		return fmt.Errorf("%s: number of steps must be positive, got %d", op, steps)
+	}
+
+	m, err := s.newMigrate()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer closeMigrate(m)
+
+	return migrateResult(op, m.Steps(-steps))
+}
+
+// MigrateTo migrates the schema up or down to the version.
+func (s *Storage) MigrateTo(version uint) error {
+	const op = "storage.postgres.MigrateTo"
+
+	m, err := s.newMigrate()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer closeMigrate(m)
+
+	return migrateResult(op, m.Migrate(version))
+}
+
+// ForceVersion sets the schema version and clears the dirty flag without running migrations.
+// It is used to recover after a failed migration was fixed by hand. Version -1 resets
+// the schema to no applied migrations, e.g. after the first migration failed.
+func (s *Storage) ForceVersion(version int) error {
+	const op = "storage.postgres.ForceVersion"
+
+	if version < -1 {
+		return fmt.Errorf("%s: version must not be less than -1, got %d", op, version)
+	}
+
+	m, err := s.newMigrate()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer closeMigrate(m)
+
+	return migrateResult(op, m.Force(version))
+}
+
+// migrateResult treats "no change" as success, the schema is already at the requested version.
+func migrateResult(op string, err error) error {
+	if err == nil || errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+// LatestMigrationVersion returns the version of the last embedded migration,
+// the database schema is expected to be at this version after migrations.
+func LatestMigrationVersion() (uint, error) {
+	const op = "storage.postgres.LatestMigrationVersion"
+
+	driver, err := migrationsSource()
+	if err != nil {
+		return 0, fmt.Errorf("%s: unable to open migrations: %w", op, err)
+	}
+
+	defer func() {
+		_ = driver.Close()
+	}()
+
+	version, err := driver.First()
+	if err != nil {
+		return 0, fmt.Errorf("%s: unable to read migrations: %w", op, err)
+	}
+
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%s: unable to read migrations: %w", op, err)
 		}
+
+		version = next
 	}
+}
 
-	log.Debug("Migrations successfully completed")
+// SchemaVersion returns the current migration version of the database schema.
+// Dirty is true if the last migration failed.
+func (s *Storage) SchemaVersion(ctx context.Context) (version uint, dirty bool, err error) {
+	const op = "storage.postgres.SchemaVersion"
 
-	return nil
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	err = s.db.QueryRowContext(ctx, sqlStr).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+
+		return 0, false, fmt.Errorf("%s: failed to get schema version: %w", op, err)
+	}
+
+	return version, dirty, nil
 }
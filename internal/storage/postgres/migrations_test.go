@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"github.com/stretchr/testify/require"
+	"io/fs"
+	"song-library/migrations"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLatestMigrationVersion(t *testing.T) {
+	names, err := fs.Glob(migrations.FS, "*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, names)
+
+	var expected uint
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		require.NoError(t, err, name)
+
+		expected = max(expected, uint(version))
+	}
+
+	version, err := LatestMigrationVersion()
+	require.NoError(t, err)
+	require.Equal(t, expected, version)
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"song-library/internal/models"
+	"time"
+)
+
+// outboxAdd adds the event to the outbox in the transaction of the change,
+// the event is published by the outbox relay if the transaction is committed.
+func (s *Storage) outboxAdd(ctx context.Context, q querier, event string, song models.Song) error {
+	payload, err := json.Marshal(song)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox event: %w", err)
+	}
+
+	_, err = q.ExecContext(ctx, `INSERT INTO outbox (event, payload) VALUES ($1, $2)`, event, payload)
+	if err != nil {
+		return fmt.Errorf("failed to add outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// OutboxClaim locks up to limit unpublished events that are due for lockFor and counts
+// the attempt, the oldest first. Events locked by other relays are skipped, the events
+// of a crashed relay are claimed again when their lock expires.
+func (s *Storage) OutboxClaim(ctx context.Context, limit int, lockFor time.Duration) (events []models.OutboxEvent, err error) {
+	const op = "storage.postgres.OutboxClaim"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			WITH claimed AS (
+				UPDATE outbox
+				SET attempts = attempts + 1,
+				    locked_until = now() + $2 * interval '1 millisecond'
+				WHERE id IN (
+					SELECT id
+					FROM outbox
+					WHERE published_at IS NULL
+					  AND next_attempt_at <= now()
+					  AND (locked_until IS NULL OR locked_until < now())
+					ORDER BY id
+					LIMIT $1
+					FOR UPDATE SKIP LOCKED)
+				RETURNING id, event_id, event, payload, attempts, created_at)
+			SELECT event_id, event, payload, attempts, created_at
+			FROM claimed
+			ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr, limit, lockFor.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to claim outbox events: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var event models.OutboxEvent
+		var payload []byte
+
+		err = rows.Scan(&event.ID, &event.Event, &payload, &event.Attempts, &event.OccurredAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to claim outbox events: %w", op, err)
+		}
+
+		if err = json.Unmarshal(payload, &event.Song); err != nil {
+			return nil, fmt.Errorf("%s: failed to decode outbox event %s: %w", op, event.ID, err)
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to claim outbox events: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// OutboxPublished marks the event published.
+func (s *Storage) OutboxPublished(ctx context.Context, eventID string) (err error) {
+	const op = "storage.postgres.OutboxPublished"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE outbox
+			SET published_at = now(), last_error = NULL, locked_until = NULL
+			WHERE event_id = $1`
+
+	if _, err = s.db.ExecContext(ctx, sqlStr, eventID); err != nil {
+		return fmt.Errorf("%s: failed to update outbox event: %w", op, err)
+	}
+
+	return nil
+}
+
+// OutboxRetry records the error of the failed attempt and schedules the event to be published again.
+func (s *Storage) OutboxRetry(ctx context.Context, eventID string, lastError string, nextAttemptAt time.Time) (err error) {
+	const op = "storage.postgres.OutboxRetry"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE outbox
+			SET last_error = $2, next_attempt_at = $3, locked_until = NULL
+			WHERE event_id = $1`
+
+	_, err = s.db.ExecContext(ctx, sqlStr, eventID,
+		sql.NullString{String: lastError, Valid: lastError != ""}, nextAttemptAt)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update outbox event: %w", op, err)
+	}
+
+	return nil
+}
+
+// OutboxPrune deletes the events published before the time and returns their number.
+func (s *Storage) OutboxPrune(ctx context.Context, publishedBefore time.Time) (deleted int64, err error) {
+	const op = "storage.postgres.OutboxPrune"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM outbox WHERE published_at < ($1)`, publishedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to delete outbox events: %w", op, err)
+	}
+
+	deleted, _ = result.RowsAffected()
+
+	return deleted, nil
+}
@@ -1,68 +1,118 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	_ "github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"log/slog"
-	"net"
-	"net/url"
 	"song-library/internal/config"
 	"song-library/internal/models"
 	"song-library/internal/storage"
+	"song-library/internal/tracing"
 	"time"
 )
 
 type Storage struct {
-	db *sql.DB
+	db           *sql.DB
+	dsn          string
+	database     string
+	tracer       trace.Tracer
+	queryTimeout time.Duration
 }
 
 func New(cfg *config.Config, logger *slog.Logger) (*Storage, error) {
 	const op = "storage.postgres.new"
 
-	log := logger.With(slog.String("op", op),
-		slog.String("host", cfg.PgHost),
-		slog.String("port", cfg.PgPort),
-		slog.String("user", cfg.PgUser),
-		slog.String("database", cfg.PgDatabase))
+	log := logger.With(slog.String("op", op))
+	if cfg.DatabaseURL != "" {
+		log = log.With(slog.String("database", databaseName(cfg)), slog.Bool("database_url", true))
+	} else {
+		log = log.With(
+			slog.String("host", cfg.PgHost),
+			slog.String("port", cfg.PgPort),
+			slog.String("user", cfg.PgUser),
+			slog.String("database", cfg.PgDatabase),
+			slog.String("sslmode", cfg.PgSSLMode))
+	}
 
 	log.Debug("Connecting to postgres database")
 
-	queryParameters := url.Values{}
-	queryParameters.Add("sslmode", "disable")
-
-	postgresURL := url.URL{
-		Scheme:   "postgres",
-		Host:     net.JoinHostPort(cfg.PgHost, cfg.PgPort),
-		User:     url.UserPassword(cfg.PgUser, cfg.PgPass),
-		Path:     cfg.PgDatabase,
-		RawQuery: queryParameters.Encode(),
-	}
-
-	db, err := sql.Open("postgres", postgresURL.String())
+	db, err := sql.Open("postgres", dsn(cfg))
 	if err != nil {
 		log.Error("Unable to open database", slog.Any("error", err))
 		return nil, err
 	}
 
-	if err = db.Ping(); err != nil {
+	db.SetMaxOpenConns(cfg.PgMaxOpenConns)
+	db.SetMaxIdleConns(cfg.PgMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.PgConnMaxLifetime)
+
+	if err = pingWithRetry(db, cfg.PgConnectTimeout, log); err != nil {
 		log.Error("Unable to connect to database", slog.Any("error", err))
+		_ = db.Close()
 		return nil, err
 	}
 
 	log.Debug("Successfully connected to postgres database")
 
-	pgStorage := &Storage{db: db}
+	pgStorage := &Storage{
+		db:           db,
+		dsn:          dsn(cfg),
+		database:     databaseName(cfg),
+		tracer:       otel.Tracer(tracing.InstrumentationName),
+		queryTimeout: cfg.DbQueryTimeout,
+	}
 
-	if err = pgStorage.makeMigrations(logger); err != nil {
-		log.Error("Unable to make migrations", slog.Any("error", err))
-		return nil, err
+	if cfg.AutoMigrate {
+		if err = pgStorage.makeMigrations(logger); err != nil {
+			log.Error("Unable to make migrations", slog.Any("error", err))
+			_ = db.Close()
+			return nil, err
+		}
 	}
 
 	return pgStorage, nil
 }
 
+// pingWithRetry pings the database until it is reachable or the timeout expires,
+// so the server does not fail if it starts a few seconds before the database.
+// The delay between attempts doubles up to pingMaxDelay.
+func pingWithRetry(db *sql.DB, timeout time.Duration, log *slog.Logger) error {
+	const (
+		pingMinDelay = 250 * time.Millisecond
+		pingMaxDelay = 5 * time.Second
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	delay := pingMinDelay
+
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		log.Warn("Database is not available yet",
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", delay),
+			slog.Any("error", err))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("database is not available after %d attempts: %w", attempt, err)
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, pingMaxDelay)
+	}
+}
+
 func (s *Storage) Close(log *slog.Logger) {
 	const op = "storage.postgres.Close"
 
@@ -78,98 +128,155 @@ func (s *Storage) Close(log *slog.Logger) {
 	}
 }
 
-func (s *Storage) SaveSong(groupName string, songName string) (songID int, err error) {
-	const op = "storage.postgres.SaveSong"
+// withTimeout bounds the storage operation by the configured query timeout,
+// so a slow query does not outlive the request that started it.
+func (s *Storage) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
 
-	groupID, err := s.getGroupID(groupName)
-	if err != nil {
-		return 0, fmt.Errorf("%s: failed to get group id: %w", op, err)
+	return context.WithTimeout(ctx, s.queryTimeout)
+}
+
+// Ping checks the connection to the database.
+func (s *Storage) Ping(ctx context.Context) (err error) {
+	const op = "storage.postgres.Ping"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if err = s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	sqlStr := `INSERT INTO songs (name, group_id) 
+	return nil
+}
+
+func (s *Storage) SaveSong(ctx context.Context, groupName string, songName string) (songID int, err error) {
+	const op = "storage.postgres.SaveSong"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		groupID, err := s.getGroupID(ctx, tx, groupName)
+		if err != nil {
+			return fmt.Errorf("%s: failed to get group id: %w", op, err)
+		}
+
+		sqlStr := `INSERT INTO songs (name, group_id) 
 				VALUES ($1, $2) 
 				RETURNING id`
-	err = s.db.QueryRow(sqlStr,
-		songName, groupID).Scan(&songID)
-	if err != nil {
-		return 0, fmt.Errorf("%s: failed to add new song: %w", op, err)
-	}
-	return songID, nil
-}
+		err = tx.QueryRowContext(ctx, sqlStr,
+			songName, groupID).Scan(&songID)
+		if err != nil {
+			return fmt.Errorf("%s: failed to add new song: %w", op, err)
+		}
 
-// findGroupID find the group ID based on the group name.
-func (s *Storage) findGroupID(groupName string) (groupID int, err error) {
-	sqlStr := `SELECT id 
-				FROM groups 
-				WHERE name = ($1)`
+		err = s.outboxAdd(ctx, tx, models.EventSongCreated, models.Song{GroupName: groupName, SongName: songName})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
 
-	err = s.db.QueryRow(sqlStr, groupName).Scan(&groupID)
-	if err != nil && errors.Is(err, sql.ErrNoRows) {
-		return 0, storage.ErrGroupNotFound
+		return nil
+	})
+	if err != nil {
+		return 0, err
 	}
-	return groupID, err
+
+	return songID, nil
 }
 
 // getGroupID get the group ID based on the group name.
 // If the group name is not already in the database, a new record will be added.
-func (s *Storage) getGroupID(groupName string) (groupID int, err error) {
+// The group is locked until the end of the transaction, so SongDelete does not
+// delete it as empty before the song added to it is committed.
+func (s *Storage) getGroupID(ctx context.Context, q querier, groupName string) (groupID int, err error) {
 	const op = "storage.postgres.getGroupID"
 
-	groupID, err = s.findGroupID(groupName)
-	if err != nil {
-		if errors.Is(err, storage.ErrGroupNotFound) {
-			sqlStr := `INSERT INTO groups (name) 
-						VALUES ($1)
-						RETURNING id`
-			err = s.db.QueryRow(sqlStr, groupName).Scan(&groupID)
-			if err != nil {
-				return 0, fmt.Errorf("%s: failed to add new group: %s. Err: %w", op, groupName, err)
-			}
+	sqlStr := `SELECT id
+				FROM groups
+				WHERE name = ($1)
+				FOR SHARE`
 
-			return groupID, nil
+	err = q.QueryRowContext(ctx, sqlStr, groupName).Scan(&groupID)
+	if err == nil {
+		return groupID, nil
+	}
 
-		} else {
-			return 0, fmt.Errorf("%s: failed to find group: %s. Err: %w", op, groupName, err)
-		}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("%s: failed to find group: %s. Err: %w", op, groupName, err)
+	}
+
+	// The group may be added by a concurrent transaction since it was not found,
+	// the update of the conflicting row locks it and returns its id
+	sqlStr = `INSERT INTO groups (name)
+				VALUES ($1)
+				ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+				RETURNING id`
+
+	err = q.QueryRowContext(ctx, sqlStr, groupName).Scan(&groupID)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to add new group: %s. Err: %w", op, groupName, err)
 	}
 
 	return groupID, nil
 }
 
-func (s *Storage) SongInfo(groupName string, songName string) (songDetail models.SongDetail, err error) {
-	const op = "storage.postgres.SongDetail"
+func (s *Storage) SongInfo(ctx context.Context, groupName string, songName string) (songDetail models.SongDetail, err error) {
+	const op = "storage.postgres.SongInfo"
 
-	var releaseDate time.Time
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var releaseDate, updatedAt time.Time
 	var text, link string
 
 	sqlStr := ` 
 			SELECT s.release_date,
 			       s.text,
-			       s.link
+			       s.link,
+			       s.updated_at
 			FROM songs s
-			JOIN groups g ON s.group_id = s.group_id
+			JOIN groups g ON s.group_id = g.id
 			WHERE g.name = ($1) AND s.name = ($2)`
 
-	err = s.db.QueryRow(sqlStr, groupName, songName).
-		Scan(&releaseDate, &text, &link)
+	err = s.db.QueryRowContext(ctx, sqlStr, groupName, songName).
+		Scan(&releaseDate, &text, &link, &updatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.SongDetail{}, storage.ErrSongNotFound
 		}
 
-		return models.SongDetail{}, fmt.Errorf("%s: failed to update song: %w", op, err)
+		return models.SongDetail{}, fmt.Errorf("%s: failed to find song: %w", op, err)
 	}
 
 	return models.SongDetail{
 			ReleaseDate: dateToString(releaseDate),
 			Text:        text,
-			Link:        link},
+			Link:        link,
+			UpdatedAt:   updatedAt},
 		nil
 }
 
-func (s *Storage) SongUpdate(groupName string, songName string, songDetail models.SongDetail) error {
+func (s *Storage) SongUpdate(ctx context.Context, groupName string, songName string, songDetail models.SongDetail) (err error) {
 	const op = "storage.postgres.SongUpdate"
 
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
 	releaseDate, err := time.Parse("02.01.2006", songDetail.ReleaseDate)
 
 	sqlStr := ` 
@@ -177,70 +284,176 @@ func (s *Storage) SongUpdate(groupName string, songName string, songDetail model
 			SET release_date = ($1), 
     			text = ($2),
     			link = ($3)
-			FROM groups
+			FROM groups, songs old
 			WHERE songs.group_id = groups.id
   				AND songs.name = ($4)
-  				AND groups.name = ($5)`
+  				AND groups.name = ($5)
+				AND old.id = songs.id
+			RETURNING ` + songChangedColumn
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return s.songUpdated(ctx, tx, op, sqlStr,
+			models.Song{GroupName: groupName, SongName: songName},
+			releaseDate, songDetail.Text, songDetail.Link,
+			songName, groupName)
+	})
+}
+
+// songChangedColumn is returned by the updates of a song joined with its old row as old,
+// it is true if the update changed the details like the songs_log_update trigger checks.
+const songChangedColumn = `(old.release_date, old.text, old.link)
+				IS DISTINCT FROM (songs.release_date, songs.text, songs.link)`
+
+// songUpdated runs the update of the song returning songChangedColumn and adds the song.updated
+// event to the outbox if the song is changed, so the outbox and the change feed get the same events.
+func (s *Storage) songUpdated(ctx context.Context, tx *sql.Tx, op string, sqlStr string, song models.Song, args ...any) error {
+	var changed bool
 
-	result, err := s.db.Exec(sqlStr,
-		releaseDate, songDetail.Text, songDetail.Link,
-		songName, groupName)
+	err := tx.QueryRowContext(ctx, sqlStr, args...).Scan(&changed)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrSongNotFound
+		}
+
 		return fmt.Errorf("%s: failed to update song: %w", op, err)
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		return storage.ErrSongNotFound
+	if !changed {
+		return nil
+	}
+
+	if err = s.outboxAdd(ctx, tx, models.EventSongUpdated, song); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (s *Storage) SongDelete(groupName string, songName string) (songID int, err error) {
-	const op = "storage.postgres.SongDelete"
+// SongEnrich fills the empty details of the song, the details already set are not changed.
+// A changed song gets the song.updated event like SongUpdate.
+func (s *Storage) SongEnrich(ctx context.Context, groupName string, songName string, songDetail models.SongDetail) (err error) {
+	const op = "storage.postgres.SongEnrich"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	// An incorrect date is not filled
+	var releaseDate sql.NullTime
+	if date, err := time.Parse("02.01.2006", songDetail.ReleaseDate); err == nil {
+		releaseDate = sql.NullTime{Time: date, Valid: true}
+	}
 
 	sqlStr := `
-  			DELETE FROM songs
-			WHERE name = ($1)
-  			    AND group_id IN (SELECT id FROM groups WHERE name = ($2))
-  			RETURNING id`
+			UPDATE songs
+			SET release_date = CASE WHEN songs.release_date = '0001-01-01'::DATE
+			                        THEN COALESCE(($1)::DATE, songs.release_date)
+			                        ELSE songs.release_date END,
+			    text = CASE WHEN COALESCE(songs.text, '') = '' THEN ($2) ELSE songs.text END,
+			    link = CASE WHEN COALESCE(songs.link, '') = '' THEN ($3) ELSE songs.link END
+			FROM groups, songs old
+			WHERE songs.group_id = groups.id
+				AND songs.name = ($4)
+				AND groups.name = ($5)
+				AND old.id = songs.id
+			RETURNING ` + songChangedColumn
+
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		return s.songUpdated(ctx, tx, op, sqlStr,
+			models.Song{GroupName: groupName, SongName: songName},
+			releaseDate, songDetail.Text, songDetail.Link,
+			songName, groupName)
+	})
+}
 
-	err = s.db.QueryRow(sqlStr, songName, groupName).Scan(&songID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return 0, storage.ErrSongNotFound
+func (s *Storage) SongDelete(ctx context.Context, groupName string, songName string) (songID int, err error) {
+	const op = "storage.postgres.SongDelete"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		// Lock the group, so no song is added to it until the group is cleaned up
+		sqlStr := `
+				SELECT id
+				FROM groups
+				WHERE name = ($1)
+				FOR UPDATE`
+
+		var groupID int
+
+		err := tx.QueryRowContext(ctx, sqlStr, groupName).Scan(&groupID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return storage.ErrSongNotFound
+			}
+
+			return fmt.Errorf("%s: failed to find group: %w", op, err)
 		}
 
-		return 0, fmt.Errorf("%s: failed to delete song: %w", op, err)
-	}
+		sqlStr = `
+				DELETE FROM songs
+				WHERE name = ($1) AND group_id = ($2)
+				RETURNING id`
+
+		err = tx.QueryRowContext(ctx, sqlStr, songName, groupID).Scan(&songID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return storage.ErrSongNotFound
+			}
 
-	// Song was successfully deleted
+			return fmt.Errorf("%s: failed to delete song: %w", op, err)
+		}
 
-	// Try to delete group
+		// Delete the group if it has no songs left
+		sqlStr = `
+				DELETE FROM groups
+				WHERE id = ($1)
+					AND NOT EXISTS (SELECT 1 FROM songs WHERE group_id = ($1))`
 
-	sqlStr = `
-		DELETE FROM groups
-  		WHERE name = ($1)`
+		_, err = tx.ExecContext(ctx, sqlStr, groupID)
+		if err != nil {
+			return fmt.Errorf("%s: failed to delete empty group: %w", op, err)
+		}
 
-	// If there are other songs by this group,
-	// then Exec() will return a not-nil error
-	// and the group will not be deleted.
+		err = s.outboxAdd(ctx, tx, models.EventSongDeleted, models.Song{GroupName: groupName, SongName: songName})
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
 
-	_, _ = s.db.Exec(sqlStr, groupName)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
 
 	return songID, nil
 }
 
-func (s *Storage) SongsGet(filter models.SongWithDetail, page int, limit int) (songs []models.SongWithDetail, err error) {
+func (s *Storage) SongsGet(ctx context.Context, filter models.SongWithDetail, page int, limit int) (songs []models.SongWithDetail, err error) {
 	const op = "storage.postgres.SongGet"
 
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
 	sqlStr := ` 
 			SELECT	g.name,
 			    	s.name,
 			    	s.release_date,
 			       	s.text,
-			       	s.link
+			       	s.link,
+			       	s.link_status,
+			       	s.link_status_code,
+			       	s.link_redirect_to,
+			       	s.link_checked_at
 			FROM songs s
 			JOIN groups g ON s.group_id = g.id
 			WHERE true
@@ -269,6 +482,19 @@ func (s *Storage) SongsGet(filter models.SongWithDetail, page int, limit int) (s
 		sqlStr += fmt.Sprintf("AND s.release_date = ($%d) ", len(arguments))
 	}
 
+	if filter.LinkCheck != nil {
+		if filter.LinkCheck.Status == models.LinkUnchecked {
+			sqlStr += "AND s.link_status IS NULL "
+		} else {
+			arguments = append(arguments, filter.LinkCheck.Status)
+			sqlStr += fmt.Sprintf("AND s.link_status = ($%d) ", len(arguments))
+		}
+	}
+
+	// Pages are stable only with a fixed order, e.g. for StreamList reading page by page
+	sqlStr += `
+			ORDER BY s.id `
+
 	offset := (page - 1) * limit
 	arguments = append(arguments, offset)
 	sqlStr += fmt.Sprintf(`
@@ -277,7 +503,7 @@ func (s *Storage) SongsGet(filter models.SongWithDetail, page int, limit int) (s
 	arguments = append(arguments, limit)
 	sqlStr += fmt.Sprintf("LIMIT ($%d) ", len(arguments))
 
-	rows, err := s.db.Query(sqlStr, arguments...)
+	rows, err := s.db.QueryContext(ctx, sqlStr, arguments...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to query songs: %w", op, err)
 	}
@@ -288,26 +514,78 @@ func (s *Storage) SongsGet(filter models.SongWithDetail, page int, limit int) (s
 
 	for rows.Next() {
 		var song models.SongWithDetail
-		var relDate time.Time
-
-		err = rows.Scan(
-			&song.GroupName,
-			&song.SongName,
-			&relDate,
-			&song.SongDetail.Text,
-			&song.SongDetail.Link)
+
+		song, err = scanSongWithDetail(rows)
 		if err != nil {
 			return nil, fmt.Errorf("%s: failed to query songs: %w", op, err)
 		}
 
-		song.SongDetail.ReleaseDate = dateToString(relDate)
-
 		songs = append(songs, song)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query songs: %w", op, err)
+	}
+
 	return songs, nil
 }
 
+// SongsUpdatedAt returns the time of the last change of the library:
+// a song was added, updated or deleted.
+func (s *Storage) SongsUpdatedAt(ctx context.Context) (updatedAt time.Time, err error) {
+	const op = "storage.postgres.SongsUpdatedAt"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `SELECT updated_at FROM library_changes`
+
+	err = s.db.QueryRowContext(ctx, sqlStr).Scan(&updatedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: failed to get library modification time: %w", op, err)
+	}
+
+	return updatedAt, nil
+}
+
+// scanSongWithDetail scans the row of the columns selected by SongsGet.
+func scanSongWithDetail(rows *sql.Rows) (song models.SongWithDetail, err error) {
+	var relDate time.Time
+	var linkStatus, linkRedirectTo sql.NullString
+	var linkStatusCode sql.NullInt32
+	var linkCheckedAt sql.NullTime
+
+	err = rows.Scan(
+		&song.GroupName,
+		&song.SongName,
+		&relDate,
+		&song.SongDetail.Text,
+		&song.SongDetail.Link,
+		&linkStatus,
+		&linkStatusCode,
+		&linkRedirectTo,
+		&linkCheckedAt)
+	if err != nil {
+		return models.SongWithDetail{}, err
+	}
+
+	song.SongDetail.ReleaseDate = dateToString(relDate)
+
+	if linkStatus.Valid {
+		song.LinkCheck = &models.LinkCheck{
+			Status:     models.LinkStatus(linkStatus.String),
+			StatusCode: int(linkStatusCode.Int32),
+			RedirectTo: linkRedirectTo.String,
+			CheckedAt:  linkCheckedAt.Time,
+		}
+	}
+
+	return song, nil
+}
+
 func dateToString(date time.Time) (dateString string) {
 	if !date.IsZero() {
 		dateString = date.Format("02.01.2006")
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"song-library/internal/models"
+)
+
+// Stats returns the connection pool statistics of the database.
+func (s *Storage) Stats() sql.DBStats {
+	return s.db.Stats()
+}
+
+func (s *Storage) LibraryStats(ctx context.Context) (stats models.LibraryStats, err error) {
+	const op = "storage.postgres.LibraryStats"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT (SELECT count(*) FROM songs),
+			       (SELECT count(*) FROM groups),
+			       (SELECT count(*) FROM song_translations)`
+
+	err = s.db.QueryRowContext(ctx, sqlStr).Scan(&stats.Songs, &stats.Groups, &stats.Translations)
+	if err != nil {
+		return models.LibraryStats{}, fmt.Errorf("%s: failed to count library: %w", op, err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"song-library/internal/storage"
+)
+
+// startSpan starts the client span of the storage operation.
+// The span is named by the op constant of the operation, e.g. "storage.postgres.SaveSong".
+// The span is a child of the request span carried by ctx.
+func (s *Storage) startSpan(ctx context.Context, op string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBNamespace(s.database),
+			semconv.DBOperationName(op),
+		),
+	)
+}
+
+// endSpan records the error of the operation and ends the span.
+// Not found and already exists errors are expected results, they do not fail the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !isExpected(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func isExpected(err error) bool {
+	return errors.Is(err, storage.ErrSongNotFound) ||
+		errors.Is(err, storage.ErrGroupNotFound) ||
+		errors.Is(err, storage.ErrSongExists) ||
+		errors.Is(err, storage.ErrTranslationNotFound) ||
+		errors.Is(err, storage.ErrTranslationExists) ||
+		errors.Is(err, storage.ErrAPIKeyNotFound) ||
+		errors.Is(err, storage.ErrJobNotFound) ||
+		errors.Is(err, storage.ErrNoJobs) ||
+		errors.Is(err, storage.ErrWebhookNotFound) ||
+		errors.Is(err, storage.ErrWebhookDeliveryNotFound)
+}
@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"song-library/internal/storage"
+	"testing"
+)
+
+func TestStorageSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	s := &Storage{database: "songs", tracer: provider.Tracer("test")}
+
+	// The span of the request handled by the server
+	ctx, requestSpan := provider.Tracer("test").Start(context.Background(), "GET /info")
+
+	_, span := s.startSpan(ctx, "storage.postgres.SongInfo")
+	endSpan(span, storage.ErrSongNotFound)
+
+	_, span = s.startSpan(ctx, "storage.postgres.SongUpdate")
+	endSpan(span, errors.New("connection refused"))
+
+	requestSpan.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	for _, span := range spans[:2] {
+		require.Equal(t, requestSpan.SpanContext().TraceID(), span.SpanContext().TraceID())
+		require.Equal(t, requestSpan.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+
+	require.Equal(t, "storage.postgres.SongInfo", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
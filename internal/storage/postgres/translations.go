@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+)
+
+// pqUniqueViolation is the PostgreSQL error code of unique constraint violation.
+const pqUniqueViolation = "23505"
+
+func (s *Storage) TranslationSave(ctx context.Context, groupName string, songName string, lang string, text string) (translationID int, err error) {
+	const op = "storage.postgres.TranslationSave"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			INSERT INTO song_translations (song_id, lang, text)
+			SELECT s.id, ($3), ($4)
+			FROM songs s
+			JOIN groups g ON s.group_id = g.id
+			WHERE g.name = ($1) AND s.name = ($2)
+			RETURNING id`
+
+	err = s.db.QueryRowContext(ctx, sqlStr, groupName, songName, lang, text).Scan(&translationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, storage.ErrSongNotFound
+		}
+
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return 0, storage.ErrTranslationExists
+		}
+
+		return 0, fmt.Errorf("%s: failed to add translation: %w", op, err)
+	}
+
+	return translationID, nil
+}
+
+func (s *Storage) TranslationUpdate(ctx context.Context, groupName string, songName string, lang string, text string) (err error) {
+	const op = "storage.postgres.TranslationUpdate"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE song_translations t
+			SET text = ($4)
+			FROM songs s
+			JOIN groups g ON s.group_id = g.id
+			WHERE t.song_id = s.id
+				AND g.name = ($1)
+				AND s.name = ($2)
+				AND t.lang = ($3)`
+
+	result, err := s.db.ExecContext(ctx, sqlStr, groupName, songName, lang, text)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update translation: %w", op, err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrTranslationNotFound
+	}
+
+	return nil
+}
+
+// Translations returns all translations of the song ordered by language tag.
+func (s *Storage) Translations(ctx context.Context, groupName string, songName string) (translations []models.SongTranslation, err error) {
+	const op = "storage.postgres.Translations"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT t.lang,
+			       t.text,
+			       s.updated_at
+			FROM song_translations t
+			JOIN songs s ON t.song_id = s.id
+			JOIN groups g ON s.group_id = g.id
+			WHERE g.name = ($1) AND s.name = ($2)
+			ORDER BY t.lang`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr, groupName, songName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query translations: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		translation := models.SongTranslation{
+			Song: models.Song{GroupName: groupName, SongName: songName},
+		}
+
+		err = rows.Scan(&translation.Lang, &translation.Text, &translation.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to query translations: %w", op, err)
+		}
+
+		translations = append(translations, translation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query translations: %w", op, err)
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// querier is implemented by *sql.DB and *sql.Tx,
+// so helpers can run both inside and outside a transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withTx runs fn as a unit of work in a single transaction.
+// The transaction is committed if fn returns nil and rolled back otherwise.
+// Errors of fn are returned as is, so callers can check them with errors.Is.
+func (s *Storage) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	const op = "storage.postgres.withTx"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	// Rollback after a successful commit is a no-op
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
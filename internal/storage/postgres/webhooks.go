@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+)
+
+// WebhookCreate adds the webhook and returns it with the id and the creation time set.
+func (s *Storage) WebhookCreate(ctx context.Context, webhook models.Webhook) (_ models.Webhook, err error) {
+	const op = "storage.postgres.WebhookCreate"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `INSERT INTO webhooks (url, events, secret)
+				VALUES ($1, $2, $3)
+				RETURNING id, created_at`
+
+	err = s.db.QueryRowContext(ctx, sqlStr, webhook.URL, pq.Array(webhook.Events), webhook.Secret).
+		Scan(&webhook.ID, &webhook.CreatedAt)
+	if err != nil {
+		return models.Webhook{}, fmt.Errorf("%s: failed to add webhook: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Storage) Webhooks(ctx context.Context) (webhooks []models.Webhook, err error) {
+	const op = "storage.postgres.Webhooks"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT id, url, events, created_at
+			FROM webhooks
+			ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query webhooks: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var webhook models.Webhook
+
+		err = rows.Scan(&webhook.ID, &webhook.URL, pq.Array(&webhook.Events), &webhook.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to query webhooks: %w", op, err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query webhooks: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+// WebhookDelete deletes the webhook with its delivery log.
+func (s *Storage) WebhookDelete(ctx context.Context, webhookID int) (err error) {
+	const op = "storage.postgres.WebhookDelete"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ($1)`, webhookID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete webhook: %w", op, err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// WebhookDeliveriesCreate adds a pending delivery of the event for every webhook
+// subscribed to it and returns the ids of the deliveries to enqueue. The webhooks that already
+// have a delivery of the event id get no new one, but their pending deliveries without a job
+// are returned again, so a delivery whose job was not enqueued is not lost.
+func (s *Storage) WebhookDeliveriesCreate(ctx context.Context, eventID string, event string, payload []byte) (deliveryIDs []int64, err error) {
+	const op = "storage.postgres.WebhookDeliveriesCreate"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	// The select of the existing deliveries does not see the rows inserted by the same statement
+	sqlStr := `
+			WITH created AS (
+				INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload)
+				SELECT id, $1, $2, $3
+				FROM webhooks
+				WHERE $2 = ANY(events)
+				ON CONFLICT (webhook_id, event_id) DO NOTHING
+				RETURNING id
+			)
+			SELECT id FROM created
+			UNION ALL
+			SELECT id
+			FROM webhook_deliveries
+			WHERE event_id = $1 AND job_id IS NULL AND status = 'pending'`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr, eventID, event, payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to add webhook deliveries: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var deliveryID int64
+
+		if err = rows.Scan(&deliveryID); err != nil {
+			return nil, fmt.Errorf("%s: failed to add webhook deliveries: %w", op, err)
+		}
+
+		deliveryIDs = append(deliveryIDs, deliveryID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to add webhook deliveries: %w", op, err)
+	}
+
+	return deliveryIDs, nil
+}
+
+// WebhookDeliveryEnqueued records the job sending the delivery.
+func (s *Storage) WebhookDeliveryEnqueued(ctx context.Context, deliveryID int64, jobID int64) (err error) {
+	const op = "storage.postgres.WebhookDeliveryEnqueued"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `UPDATE webhook_deliveries SET job_id = ($2) WHERE id = ($1)`, deliveryID, jobID)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update webhook delivery: %w", op, err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// WebhookDelivery returns the delivery and the webhook it is sent to.
+func (s *Storage) WebhookDelivery(ctx context.Context, deliveryID int64) (delivery models.WebhookDelivery, webhook models.Webhook, err error) {
+	const op = "storage.postgres.WebhookDelivery"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			SELECT d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts,
+			       d.response_code, d.last_error, d.created_at, d.updated_at,
+			       w.id, w.url, w.events, w.secret, w.created_at
+			FROM webhook_deliveries d
+			JOIN webhooks w ON d.webhook_id = w.id
+			WHERE d.id = ($1)`
+
+	row := s.db.QueryRowContext(ctx, sqlStr, deliveryID)
+
+	var responseCode sql.NullInt32
+	var lastError sql.NullString
+
+	err = row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.Event, &delivery.Payload, &delivery.Status,
+		&delivery.Attempts, &responseCode, &lastError, &delivery.CreatedAt, &delivery.UpdatedAt,
+		&webhook.ID, &webhook.URL, pq.Array(&webhook.Events), &webhook.Secret, &webhook.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.WebhookDelivery{}, models.Webhook{}, storage.ErrWebhookDeliveryNotFound
+		}
+
+		return models.WebhookDelivery{}, models.Webhook{}, fmt.Errorf("%s: failed to get webhook delivery: %w", op, err)
+	}
+
+	delivery.ResponseCode = int(responseCode.Int32)
+	delivery.LastError = lastError.String
+
+	return delivery, webhook, nil
+}
+
+// WebhookDeliveryAttempt records the result of a delivery attempt.
+func (s *Storage) WebhookDeliveryAttempt(ctx context.Context, deliveryID int64, status models.WebhookDeliveryStatus,
+	responseCode int, lastError string) (err error) {
+	const op = "storage.postgres.WebhookDeliveryAttempt"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sqlStr := `
+			UPDATE webhook_deliveries
+			SET status = ($2),
+			    attempts = attempts + 1,
+			    response_code = ($3),
+			    last_error = ($4),
+			    updated_at = now()
+			WHERE id = ($1)`
+
+	result, err := s.db.ExecContext(ctx, sqlStr, deliveryID, status,
+		sql.NullInt32{Int32: int32(responseCode), Valid: responseCode != 0},
+		sql.NullString{String: lastError, Valid: lastError != ""})
+	if err != nil {
+		return fmt.Errorf("%s: failed to update webhook delivery: %w", op, err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return storage.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// WebhookDeliveries returns up to limit latest deliveries of the webhook, the newest first.
+func (s *Storage) WebhookDeliveries(ctx context.Context, webhookID int, limit int) (deliveries []models.WebhookDelivery, err error) {
+	const op = "storage.postgres.WebhookDeliveries"
+
+	ctx, span := s.startSpan(ctx, op)
+	defer func() { endSpan(span, err) }()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var exists bool
+
+	err = s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM webhooks WHERE id = ($1))`, webhookID).
+		Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get webhook: %w", op, err)
+	}
+
+	if !exists {
+		return nil, storage.ErrWebhookNotFound
+	}
+
+	sqlStr := `
+			SELECT id, webhook_id, event, payload, status, attempts,
+			       response_code, last_error, created_at, updated_at
+			FROM webhook_deliveries
+			WHERE webhook_id = ($1)
+			ORDER BY id DESC
+			LIMIT ($2)`
+
+	rows, err := s.db.QueryContext(ctx, sqlStr, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to query webhook deliveries: %w", op, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		var responseCode sql.NullInt32
+		var lastError sql.NullString
+
+		err = rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.Event, &delivery.Payload, &delivery.Status,
+			&delivery.Attempts, &responseCode, &lastError, &delivery.CreatedAt, &delivery.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to query webhook deliveries: %w", op, err)
+		}
+
+		delivery.ResponseCode = int(responseCode.Int32)
+		delivery.LastError = lastError.String
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to query webhook deliveries: %w", op, err)
+	}
+
+	return deliveries, nil
+}
@@ -6,4 +6,18 @@ var (
 	ErrSongExists    = errors.New("song already exists")
 	ErrSongNotFound  = errors.New("song not found")
 	ErrGroupNotFound = errors.New("group not found")
+
+	ErrTranslationExists   = errors.New("translation already exists")
+	ErrTranslationNotFound = errors.New("translation not found")
+
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobLost is returned when the result of a job attempt is recorded after the job was claimed again
+	ErrJobLost = errors.New("job is no longer owned by the attempt")
+
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrNoJobs is returned when no job is ready to run
+	ErrNoJobs = errors.New("no jobs to run")
 )
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"log/slog"
+	"song-library/internal/config"
+)
+
+// InstrumentationName is the name of the tracers of the service.
+const InstrumentationName = "song-library"
+
+// Setup installs the global tracer provider exporting spans with OTLP over HTTP.
+// The exporter is configured by the standard OTEL_EXPORTER_OTLP_* environment variables,
+// e.g. OTEL_EXPORTER_OTLP_ENDPOINT=http://collector:4318.
+// If tracing is disabled, the global no-op provider is kept.
+// The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, cfg *config.Config, log *slog.Logger) (shutdown func(context.Context) error, err error) {
+	const op = "tracing.Setup"
+
+	log = log.With(slog.String("op", op))
+
+	// W3C trace context is propagated even if tracing is disabled
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !cfg.TracingEnabled {
+		log.Info("Tracing disabled")
+
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.TracingServiceName),
+		semconv.DeploymentEnvironment(cfg.Environment),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	log.Info("Tracing enabled",
+		slog.String("service", cfg.TracingServiceName),
+		slog.Float64("sample_ratio", cfg.TracingSampleRatio))
+
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,95 @@
+package translation
+
+import (
+	"context"
+	"golang.org/x/text/language"
+	"net/http"
+	"song-library/internal/models"
+)
+
+// Requested returns the languages requested by the client in order of preference.
+// The 'lang' query parameter takes precedence over the Accept-Language header.
+// Malformed values are ignored.
+func Requested(lang string, acceptLanguage string) []language.Tag {
+	if lang != "" {
+		if tag, err := language.Parse(lang); err == nil {
+			return []language.Tag{tag}
+		}
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return nil
+	}
+
+	return tags
+}
+
+// RequestedFromRequest returns the languages requested by 'lang' query parameter
+// or Accept-Language header of the request.
+func RequestedFromRequest(r *http.Request) []language.Tag {
+	return Requested(r.URL.Query().Get("lang"), r.Header.Get("Accept-Language"))
+}
+
+// Match returns the translation that best matches the requested languages.
+// If no translation matches, false is returned and the original text should be used.
+func Match(translations []models.SongTranslation, requested []language.Tag) (models.SongTranslation, bool) {
+	if len(translations) == 0 || len(requested) == 0 {
+		return models.SongTranslation{}, false
+	}
+
+	// The first supported tag is the fallback of the matcher,
+	// language.Und stands for the original text of the song.
+	supported := make([]language.Tag, 0, len(translations)+1)
+	supported = append(supported, language.Und)
+
+	for _, translation := range translations {
+		supported = append(supported, language.Make(translation.Lang))
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(requested...)
+	if index == 0 || confidence == language.No {
+		return models.SongTranslation{}, false
+	}
+
+	return translations[index-1], true
+}
+
+// Translator finds the translations of the song.
+type Translator interface {
+	Translations(ctx context.Context, groupName string, songName string) ([]models.SongTranslation, error)
+}
+
+// Translate replaces the text of the song detail by the translation that best matches
+// the requested languages and returns its language. The original text is kept and
+// the language is empty if no languages are requested or no translation matches.
+func Translate(ctx context.Context, translator Translator, song models.Song, songDetail *models.SongDetail,
+	requested []language.Tag) (string, error) {
+	if len(requested) == 0 {
+		return "", nil
+	}
+
+	translations, err := translator.Translations(ctx, song.GroupName, song.SongName)
+	if err != nil {
+		return "", err
+	}
+
+	songTranslation, ok := Match(translations, requested)
+	if !ok {
+		return "", nil
+	}
+
+	songDetail.Text = songTranslation.Text
+
+	return songTranslation.Lang, nil
+}
+
+// Canonical validates the BCP 47 language tag and returns it in canonical form.
+func Canonical(lang string) (string, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", err
+	}
+
+	return tag.String(), nil
+}
@@ -0,0 +1,130 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "song-library/internal/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// WebhookDeliveriesCreate provides a mock function with given fields: ctx, eventID, event, payload
+func (_m *Storage) WebhookDeliveriesCreate(ctx context.Context, eventID string, event string, payload []byte) ([]int64, error) {
+	ret := _m.Called(ctx, eventID, event, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveriesCreate")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) ([]int64, error)); ok {
+		return rf(ctx, eventID, event, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) []int64); ok {
+		r0 = rf(ctx, eventID, event, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, eventID, event, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDelivery provides a mock function with given fields: ctx, deliveryID
+func (_m *Storage) WebhookDelivery(ctx context.Context, deliveryID int64) (models.WebhookDelivery, models.Webhook, error) {
+	ret := _m.Called(ctx, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDelivery")
+	}
+
+	var r0 models.WebhookDelivery
+	var r1 models.Webhook
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.WebhookDelivery, models.Webhook, error)); ok {
+		return rf(ctx, deliveryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.WebhookDelivery); ok {
+		r0 = rf(ctx, deliveryID)
+	} else {
+		r0 = ret.Get(0).(models.WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) models.Webhook); ok {
+		r1 = rf(ctx, deliveryID)
+	} else {
+		r1 = ret.Get(1).(models.Webhook)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int64) error); ok {
+		r2 = rf(ctx, deliveryID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookDeliveryAttempt provides a mock function with given fields: ctx, deliveryID, status, responseCode, lastError
+func (_m *Storage) WebhookDeliveryAttempt(ctx context.Context, deliveryID int64, status models.WebhookDeliveryStatus, responseCode int, lastError string) error {
+	ret := _m.Called(ctx, deliveryID, status, responseCode, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.WebhookDeliveryStatus, int, string) error); ok {
+		r0 = rf(ctx, deliveryID, status, responseCode, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryEnqueued provides a mock function with given fields: ctx, deliveryID, jobID
+func (_m *Storage) WebhookDeliveryEnqueued(ctx context.Context, deliveryID int64, jobID int64) error {
+	ret := _m.Called(ctx, deliveryID, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookDeliveryEnqueued")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, deliveryID, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Storage {
+	mock := &Storage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,223 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"song-library/internal/jobs"
+	"song-library/internal/models"
+	"song-library/internal/storage"
+	"strconv"
+	"time"
+)
+
+// JobKindDeliver is the kind of jobs delivering events to webhooks.
+const JobKindDeliver = "webhook.deliver"
+
+// Headers of webhook requests
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const userAgent = "song-library-webhooks/1.0"
+
+//go:generate go run github.com/vektra/mockery/v2@v2.43.2 --name=Storage
+type Storage interface {
+	WebhookDeliveriesCreate(ctx context.Context, eventID string, event string, payload []byte) (deliveryIDs []int64, err error)
+	WebhookDeliveryEnqueued(ctx context.Context, deliveryID int64, jobID int64) error
+	WebhookDelivery(ctx context.Context, deliveryID int64) (models.WebhookDelivery, models.Webhook, error)
+	WebhookDeliveryAttempt(ctx context.Context, deliveryID int64, status models.WebhookDeliveryStatus,
+		responseCode int, lastError string) error
+}
+
+// deliverPayload is the payload of JobKindDeliver jobs.
+type deliverPayload struct {
+	DeliveryID int64 `json:"deliveryId"`
+}
+
+// Dispatcher sends song lifecycle events to the subscribed webhooks. Events come from
+// the outbox relay, so the webhooks get every committed change. Every delivery
+// is a background job, so failed deliveries are retried with the backoff of the job queue.
+type Dispatcher struct {
+	storage Storage
+	jobs    jobs.Enqueuer
+	client  *http.Client
+	log     *slog.Logger
+	now     func() time.Time
+}
+
+// New returns the dispatcher enqueueing the deliveries to jobEnqueuer,
+// timeout limits a delivery request.
+func New(storage Storage, jobEnqueuer jobs.Enqueuer, timeout time.Duration, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		storage: storage,
+		jobs:    jobEnqueuer,
+		client:  &http.Client{Timeout: timeout},
+		log:     log.With(slog.String("op", "webhooks")),
+		now:     time.Now,
+	}
+}
+
+// SongEvent logs a delivery of the outbox event for every subscribed webhook and enqueues
+// the deliveries, it is the outbox.SinkFunc of the webhooks. An event published again
+// gets no deliveries for the webhooks it was already logged for, only the deliveries
+// whose jobs were not enqueued are enqueued again.
+func (d *Dispatcher) SongEvent(ctx context.Context, event models.OutboxEvent) error {
+	const op = "webhooks.SongEvent"
+
+	payload, err := json.Marshal(models.WebhookEvent{
+		ID:         event.ID,
+		Event:      event.Event,
+		OccurredAt: event.OccurredAt.UTC(),
+		Song:       event.Song,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal event: %w", op, err)
+	}
+
+	deliveryIDs, err := d.storage.WebhookDeliveriesCreate(ctx, event.ID, event.Event, payload)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var errs []error
+
+	for _, deliveryID := range deliveryIDs {
+		jobID, err := d.jobs.Enqueue(ctx, JobKindDeliver, deliverPayload{DeliveryID: deliveryID})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: delivery %d: %w", op, deliveryID, err))
+			continue
+		}
+
+		// If the job is not recorded, the delivery is enqueued again with the event and may be sent twice
+		if err = d.storage.WebhookDeliveryEnqueued(ctx, deliveryID, jobID); err != nil {
+			errs = append(errs, fmt.Errorf("%s: delivery %d: %w", op, deliveryID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// HandleJob is the jobs.Handler of JobKindDeliver jobs. It sends the signed event to the webhook
+// and logs the attempt. Responses other than 2xx fail the attempt, the delivery is failed
+// when the job gives up.
+func (d *Dispatcher) HandleJob(ctx context.Context, job models.Job) error {
+	const op = "webhooks.HandleJob"
+
+	var payload deliverPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return jobs.Permanent(fmt.Errorf("%s: failed to decode payload: %w", op, err))
+	}
+
+	delivery, webhook, err := d.storage.WebhookDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookDeliveryNotFound) {
+			// The webhook is deleted with its deliveries
+			return nil
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	responseCode, err := d.send(ctx, webhook, delivery)
+	permanent := errors.As(err, new(permanentStatusError))
+
+	status := models.DeliveryDelivered
+	lastError := ""
+
+	if err != nil {
+		lastError = err.Error()
+
+		status = models.DeliveryPending
+		if permanent || job.Attempts >= job.MaxAttempts {
+			status = models.DeliveryFailed
+		}
+	}
+
+	// The attempt is logged even if the job was cancelled on shutdown
+	attemptErr := d.storage.WebhookDeliveryAttempt(context.WithoutCancel(ctx), delivery.ID, status, responseCode, lastError)
+	if attemptErr != nil {
+		d.log.ErrorContext(ctx, "Failed to log webhook delivery",
+			slog.Int64("delivery_id", delivery.ID),
+			slog.Any("error", attemptErr))
+	}
+
+	switch {
+	case permanent:
+		return jobs.Permanent(fmt.Errorf("%s: %w", op, err))
+	case err != nil:
+		return fmt.Errorf("%s: %w", op, err)
+	default:
+		return nil
+	}
+}
+
+// permanentStatusError is a response meaning the webhook is gone, it is not retried.
+type permanentStatusError struct {
+	code int
+}
+
+func (e permanentStatusError) Error() string {
+	return fmt.Sprintf("webhook responded with status %d", e.code)
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook models.Webhook, delivery models.WebhookDelivery) (responseCode int, err error) {
+	timestamp := strconv.FormatInt(d.now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return resp.StatusCode, nil
+	case resp.StatusCode == http.StatusGone:
+		return resp.StatusCode, permanentStatusError{code: resp.StatusCode}
+	default:
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+}
+
+// Sign returns the X-Webhook-Signature of the payload: "sha256=" and hex encoded
+// HMAC-SHA256 of the X-Webhook-Timestamp, a dot and the body keyed by the webhook secret.
+// Receivers compute the same value and reject old timestamps to prevent replays.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature of the payload is correct.
+func Verify(secret string, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
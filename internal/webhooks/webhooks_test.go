@@ -0,0 +1,209 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"song-library/internal/jobs"
+	"song-library/internal/logger/slogdiscard"
+	"song-library/internal/models"
+	"song-library/internal/webhooks/mocks"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeJobs fails the first failures jobs and keeps the payloads of the enqueued ones.
+type fakeJobs struct {
+	payloads []any
+	failures int
+}
+
+func (f *fakeJobs) Enqueue(ctx context.Context, kind string, payload any) (int64, error) {
+	if kind != JobKindDeliver {
+		return 0, errors.New("unexpected job kind")
+	}
+
+	if f.failures > 0 {
+		f.failures--
+		return 0, errors.New("queue is down")
+	}
+
+	f.payloads = append(f.payloads, payload)
+
+	return int64(len(f.payloads)), nil
+}
+
+func newTestDispatcher(storage Storage, jobEnqueuer jobs.Enqueuer) *Dispatcher {
+	dispatcher := New(storage, jobEnqueuer, time.Second, slogdiscard.NewDiscardLogger())
+	dispatcher.now = func() time.Time { return now }
+
+	return dispatcher
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"event":"song.created"}`)
+
+	signature := Sign("secret", "1727784000", body)
+
+	require.Equal(t, "sha256=", signature[:7])
+	require.Len(t, signature, 7+64)
+	require.True(t, Verify("secret", "1727784000", body, signature))
+	require.False(t, Verify("other secret", "1727784000", body, signature))
+	require.False(t, Verify("secret", "1727784001", body, signature))
+	require.False(t, Verify("secret", "1727784000", []byte(`{"event":"song.deleted"}`), signature))
+}
+
+func TestSongEvent(t *testing.T) {
+	event := models.OutboxEvent{
+		ID:         "5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a",
+		Event:      models.EventSongCreated,
+		OccurredAt: now,
+		Song:       models.Song{GroupName: "Muse", SongName: "Uprising"},
+		Attempts:   1,
+	}
+
+	payload, err := json.Marshal(models.WebhookEvent{
+		ID:         event.ID,
+		Event:      event.Event,
+		OccurredAt: event.OccurredAt,
+		Song:       event.Song,
+	})
+	require.NoError(t, err)
+
+	storageMock := mocks.NewStorage(t)
+	storageMock.On("WebhookDeliveriesCreate", mock.Anything, event.ID, models.EventSongCreated, payload).
+		Return([]int64{3, 4}, nil).Once()
+	storageMock.On("WebhookDeliveryEnqueued", mock.Anything, int64(3), int64(1)).Return(nil).Once()
+	storageMock.On("WebhookDeliveryEnqueued", mock.Anything, int64(4), int64(2)).Return(nil).Once()
+
+	jobsFake := &fakeJobs{}
+
+	err = newTestDispatcher(storageMock, jobsFake).SongEvent(context.Background(), event)
+	require.NoError(t, err)
+	require.Equal(t, []any{deliverPayload{DeliveryID: 3}, deliverPayload{DeliveryID: 4}}, jobsFake.payloads)
+}
+
+func TestSongEventEnqueueFailed(t *testing.T) {
+	event := models.OutboxEvent{
+		ID:         "5f0c8d7e-3c1a-4b8e-9d0a-1f2e3d4c5b6a",
+		Event:      models.EventSongDeleted,
+		OccurredAt: now,
+		Song:       models.Song{GroupName: "Muse", SongName: "Uprising"},
+		Attempts:   1,
+	}
+
+	storageMock := mocks.NewStorage(t)
+	// The delivery is added by the first attempt and has no job, so the storage returns it again
+	storageMock.On("WebhookDeliveriesCreate", mock.Anything, event.ID, models.EventSongDeleted, mock.Anything).
+		Return([]int64{3}, nil).Twice()
+	storageMock.On("WebhookDeliveryEnqueued", mock.Anything, int64(3), int64(1)).Return(nil).Once()
+
+	jobsFake := &fakeJobs{failures: 1}
+	dispatcher := newTestDispatcher(storageMock, jobsFake)
+
+	// The outbox relay publishes the event again after the error
+	require.Error(t, dispatcher.SongEvent(context.Background(), event))
+	require.Empty(t, jobsFake.payloads)
+
+	require.NoError(t, dispatcher.SongEvent(context.Background(), event))
+	require.Equal(t, []any{deliverPayload{DeliveryID: 3}}, jobsFake.payloads)
+}
+
+func TestHandleJob(t *testing.T) {
+	cases := []struct {
+		name         string
+		responseCode int
+		attempts     int
+		status       models.WebhookDeliveryStatus
+		lastError    string
+		err          bool
+		permanent    bool
+	}{
+		{
+			name:         "Delivered",
+			responseCode: http.StatusNoContent,
+			attempts:     1,
+			status:       models.DeliveryDelivered,
+		},
+		{
+			name:         "Server error is retried",
+			responseCode: http.StatusServiceUnavailable,
+			attempts:     1,
+			status:       models.DeliveryPending,
+			lastError:    "webhook responded with status 503",
+			err:          true,
+		},
+		{
+			name:         "Failed after max attempts",
+			responseCode: http.StatusInternalServerError,
+			attempts:     3,
+			status:       models.DeliveryFailed,
+			lastError:    "webhook responded with status 500",
+			err:          true,
+		},
+		{
+			name:         "Gone webhook is not retried",
+			responseCode: http.StatusGone,
+			attempts:     1,
+			status:       models.DeliveryFailed,
+			lastError:    "webhook responded with status 410",
+			err:          true,
+			permanent:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := []byte(`{"event":"song.created","song":{"group":"Muse","song":"Uprising"}}`)
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				require.Equal(t, body, received)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				require.Equal(t, models.EventSongCreated, r.Header.Get(HeaderEvent))
+				require.Equal(t, "7", r.Header.Get(HeaderDelivery))
+				require.Equal(t, strconv.FormatInt(now.Unix(), 10), r.Header.Get(HeaderTimestamp))
+				require.True(t, Verify("secret", r.Header.Get(HeaderTimestamp), received, r.Header.Get(HeaderSignature)))
+
+				w.WriteHeader(tc.responseCode)
+			}))
+			t.Cleanup(receiver.Close)
+
+			storageMock := mocks.NewStorage(t)
+			storageMock.On("WebhookDelivery", mock.Anything, int64(7)).
+				Return(
+					models.WebhookDelivery{ID: 7, WebhookID: 1, Event: models.EventSongCreated, Payload: body},
+					models.Webhook{ID: 1, URL: receiver.URL, Events: models.Events, Secret: "secret"},
+					nil).Once()
+			storageMock.On("WebhookDeliveryAttempt", mock.Anything, int64(7), tc.status, tc.responseCode, tc.lastError).
+				Return(nil).Once()
+
+			job := models.Job{
+				ID:          1,
+				Kind:        JobKindDeliver,
+				Payload:     []byte(`{"deliveryId":7}`),
+				Attempts:    tc.attempts,
+				MaxAttempts: 3,
+			}
+
+			err := newTestDispatcher(storageMock, &fakeJobs{}).HandleJob(context.Background(), job)
+			if !tc.err {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			require.Equal(t, tc.permanent, jobs.IsPermanent(err))
+		})
+	}
+}
@@ -0,0 +1,4 @@
+-- Unique group names
+DROP INDEX IF EXISTS idx_group_name_unique;
+
+CREATE INDEX IF NOT EXISTS idx_group_name ON groups (name);
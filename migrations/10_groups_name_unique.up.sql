@@ -0,0 +1,14 @@
+-- Group names are unique, so a group found by name can be locked while a song is added to it.
+-- Songs of duplicate groups move to the group with the smallest id
+UPDATE songs s
+SET group_id = d.keep_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY name) AS keep_id FROM groups) d
+WHERE s.group_id = d.id AND d.id <> d.keep_id;
+
+DELETE FROM groups g
+USING groups k
+WHERE g.name = k.name AND g.id > k.id;
+
+DROP INDEX IF EXISTS idx_group_name;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_group_name_unique ON groups (name);
@@ -0,0 +1,15 @@
+-- Touch of the library by changed rows only
+DROP TRIGGER IF EXISTS songs_touch_library_insert ON songs;
+DROP TRIGGER IF EXISTS songs_touch_library_update ON songs;
+DROP TRIGGER IF EXISTS songs_touch_library_delete ON songs;
+
+CREATE OR REPLACE FUNCTION library_changes_touch() RETURNS trigger AS $$
+BEGIN
+    UPDATE library_changes SET updated_at = now();
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER songs_touch_library
+    AFTER INSERT OR UPDATE OR DELETE ON songs
+    FOR EACH STATEMENT EXECUTE FUNCTION library_changes_touch();
@@ -0,0 +1,28 @@
+-- The library is touched only by statements that change songs. A statement changing no rows,
+-- e.g. an update of a missing song, neither changes Last-Modified of the song lists nor locks
+-- the library_changes row until commit. Transition tables are per event, so a trigger per event
+DROP TRIGGER IF EXISTS songs_touch_library ON songs;
+
+CREATE OR REPLACE FUNCTION library_changes_touch() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM changed_songs) THEN
+        UPDATE library_changes SET updated_at = now();
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER songs_touch_library_insert
+    AFTER INSERT ON songs
+    REFERENCING NEW TABLE AS changed_songs
+    FOR EACH STATEMENT EXECUTE FUNCTION library_changes_touch();
+
+CREATE TRIGGER songs_touch_library_update
+    AFTER UPDATE ON songs
+    REFERENCING NEW TABLE AS changed_songs
+    FOR EACH STATEMENT EXECUTE FUNCTION library_changes_touch();
+
+CREATE TRIGGER songs_touch_library_delete
+    AFTER DELETE ON songs
+    REFERENCING OLD TABLE AS changed_songs
+    FOR EACH STATEMENT EXECUTE FUNCTION library_changes_touch();
@@ -0,0 +1,14 @@
+-- Touch of the song and the library by changes of the content only
+DROP TRIGGER IF EXISTS songs_touch_library_update ON songs;
+DROP FUNCTION IF EXISTS library_changes_touch_update();
+
+CREATE TRIGGER songs_touch_library_update
+    AFTER UPDATE ON songs
+    REFERENCING NEW TABLE AS changed_songs
+    FOR EACH STATEMENT EXECUTE FUNCTION library_changes_touch();
+
+DROP TRIGGER IF EXISTS songs_set_updated_at ON songs;
+
+CREATE TRIGGER songs_set_updated_at
+    BEFORE UPDATE ON songs
+    FOR EACH ROW EXECUTE FUNCTION songs_set_updated_at();
@@ -0,0 +1,35 @@
+-- Only changes of the song content change its Last-Modified. Columns written in the background,
+-- e.g. by the dead link checker, do not, so cached responses of the song stay valid
+DROP TRIGGER IF EXISTS songs_set_updated_at ON songs;
+
+CREATE TRIGGER songs_set_updated_at
+    BEFORE UPDATE OF name, group_id, release_date, text, link ON songs
+    FOR EACH ROW
+    WHEN ((OLD.name, OLD.group_id, OLD.release_date, OLD.text, OLD.link)
+        IS DISTINCT FROM (NEW.name, NEW.group_id, NEW.release_date, NEW.text, NEW.link))
+    EXECUTE FUNCTION songs_set_updated_at();
+
+-- The song lists also show the link status, so a changed status touches the library
+-- and a recheck with the same result does not. Transition tables rule out a column list
+CREATE OR REPLACE FUNCTION library_changes_touch_update() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM new_songs n
+        JOIN old_songs o ON o.id = n.id
+        WHERE (o.name, o.group_id, o.release_date, o.text, o.link,
+               o.link_status, o.link_status_code, o.link_redirect_to)
+            IS DISTINCT FROM (n.name, n.group_id, n.release_date, n.text, n.link,
+                              n.link_status, n.link_status_code, n.link_redirect_to)) THEN
+        UPDATE library_changes SET updated_at = now();
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS songs_touch_library_update ON songs;
+
+CREATE TRIGGER songs_touch_library_update
+    AFTER UPDATE ON songs
+    REFERENCING OLD TABLE AS old_songs NEW TABLE AS new_songs
+    FOR EACH STATEMENT EXECUTE FUNCTION library_changes_touch_update();
@@ -0,0 +1,5 @@
+-- Webhooks are driven by the outbox relay, which publishes an event again after a failure.
+-- The outbox event id keeps a webhook from getting two deliveries of the same event
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS event_id UUID;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries (webhook_id, event_id);
@@ -0,0 +1,4 @@
+-- Jobs of webhook deliveries
+DROP INDEX IF EXISTS idx_webhook_deliveries_without_job;
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS job_id;
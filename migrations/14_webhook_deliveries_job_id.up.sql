@@ -0,0 +1,5 @@
+-- The job of a delivery is enqueued after the delivery is added, outside of its transaction.
+-- Pending deliveries without a job are enqueued again when the outbox publishes the event again
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS job_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_without_job ON webhook_deliveries (event_id) WHERE job_id IS NULL;
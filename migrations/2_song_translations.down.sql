@@ -0,0 +1,2 @@
+-- Song translations
+DROP TABLE IF EXISTS song_translations;
@@ -0,0 +1,8 @@
+-- Song translations
+CREATE TABLE IF NOT EXISTS song_translations (
+                                    id SERIAL PRIMARY KEY,
+                                    song_id INT NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+                                    lang TEXT NOT NULL,
+                                    text TEXT NOT NULL DEFAULT '',
+                                    UNIQUE (song_id, lang)
+);
@@ -0,0 +1,2 @@
+-- API keys
+DROP TABLE IF EXISTS api_keys;
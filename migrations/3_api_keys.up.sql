@@ -0,0 +1,8 @@
+-- API keys
+CREATE TABLE IF NOT EXISTS api_keys (
+                                    id SERIAL PRIMARY KEY,
+                                    name TEXT NOT NULL,
+                                    key_hash TEXT NOT NULL UNIQUE,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    revoked_at TIMESTAMPTZ
+);
@@ -0,0 +1,12 @@
+-- Modification time of songs
+DROP TRIGGER IF EXISTS songs_touch_library ON songs;
+DROP FUNCTION IF EXISTS library_changes_touch();
+DROP TABLE IF EXISTS library_changes;
+
+DROP TRIGGER IF EXISTS song_translations_touch_song ON song_translations;
+DROP FUNCTION IF EXISTS song_translations_touch_song();
+
+DROP TRIGGER IF EXISTS songs_set_updated_at ON songs;
+DROP FUNCTION IF EXISTS songs_set_updated_at();
+
+ALTER TABLE songs DROP COLUMN IF EXISTS updated_at;
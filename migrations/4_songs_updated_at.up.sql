@@ -0,0 +1,46 @@
+-- Modification time of songs, it is the Last-Modified of the song endpoints
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE OR REPLACE FUNCTION songs_set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER songs_set_updated_at
+    BEFORE UPDATE ON songs
+    FOR EACH ROW EXECUTE FUNCTION songs_set_updated_at();
+
+-- A changed translation changes the song
+CREATE OR REPLACE FUNCTION song_translations_touch_song() RETURNS trigger AS $$
+BEGIN
+    UPDATE songs SET updated_at = now()
+    WHERE id = CASE WHEN TG_OP = 'DELETE' THEN OLD.song_id ELSE NEW.song_id END;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER song_translations_touch_song
+    AFTER INSERT OR UPDATE OR DELETE ON song_translations
+    FOR EACH ROW EXECUTE FUNCTION song_translations_touch_song();
+
+-- Modification time of the whole library, it is the Last-Modified of the song lists.
+-- Deleted songs leave no rows, so the lists can not use max(songs.updated_at)
+CREATE TABLE IF NOT EXISTS library_changes (
+                                    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+                                    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO library_changes DEFAULT VALUES ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION library_changes_touch() RETURNS trigger AS $$
+BEGIN
+    UPDATE library_changes SET updated_at = now();
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER songs_touch_library
+    AFTER INSERT OR UPDATE OR DELETE ON songs
+    FOR EACH STATEMENT EXECUTE FUNCTION library_changes_touch();
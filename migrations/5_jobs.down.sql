@@ -0,0 +1,2 @@
+-- Background jobs
+DROP TABLE IF EXISTS jobs;
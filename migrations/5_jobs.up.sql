@@ -0,0 +1,19 @@
+-- Background jobs, workers claim them with SELECT ... FOR UPDATE SKIP LOCKED.
+-- Failed jobs are retried at run_at until max_attempts, then they are dead
+CREATE TABLE IF NOT EXISTS jobs (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    kind TEXT NOT NULL,
+                                    payload JSONB NOT NULL DEFAULT '{}',
+                                    status TEXT NOT NULL DEFAULT 'pending'
+                                        CHECK (status IN ('pending', 'running', 'done', 'dead')),
+                                    attempts INT NOT NULL DEFAULT 0,
+                                    max_attempts INT NOT NULL,
+                                    last_error TEXT,
+                                    run_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    locked_until TIMESTAMPTZ,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS jobs_pending_idx ON jobs (run_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS jobs_running_idx ON jobs (locked_until) WHERE status = 'running';
@@ -0,0 +1,9 @@
+-- Result of the last check of the song link
+DROP TRIGGER IF EXISTS songs_reset_link_check ON songs;
+DROP FUNCTION IF EXISTS songs_reset_link_check();
+
+ALTER TABLE songs
+    DROP COLUMN IF EXISTS link_status,
+    DROP COLUMN IF EXISTS link_status_code,
+    DROP COLUMN IF EXISTS link_redirect_to,
+    DROP COLUMN IF EXISTS link_checked_at;
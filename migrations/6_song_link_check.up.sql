@@ -0,0 +1,27 @@
+-- Result of the last check of the song link by the dead link checker, NULL status is not checked yet
+ALTER TABLE songs
+    ADD COLUMN IF NOT EXISTS link_status TEXT CHECK (link_status IN ('ok', 'broken', 'unreachable')),
+    ADD COLUMN IF NOT EXISTS link_status_code INT,
+    ADD COLUMN IF NOT EXISTS link_redirect_to TEXT,
+    ADD COLUMN IF NOT EXISTS link_checked_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_song_link_status ON songs (link_status);
+
+CREATE INDEX IF NOT EXISTS idx_song_link_checked_at ON songs (link_checked_at NULLS FIRST);
+
+-- A changed link is checked again
+CREATE OR REPLACE FUNCTION songs_reset_link_check() RETURNS trigger AS $$
+BEGIN
+    IF NEW.link IS DISTINCT FROM OLD.link THEN
+        NEW.link_status = NULL;
+        NEW.link_status_code = NULL;
+        NEW.link_redirect_to = NULL;
+        NEW.link_checked_at = NULL;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER songs_reset_link_check
+    BEFORE UPDATE OF link ON songs
+    FOR EACH ROW EXECUTE FUNCTION songs_reset_link_check();
@@ -0,0 +1,25 @@
+-- Webhook subscriptions, the secret signs the payloads so it is stored as is
+CREATE TABLE IF NOT EXISTS webhooks (
+                                    id SERIAL PRIMARY KEY,
+                                    url TEXT NOT NULL,
+                                    events TEXT[] NOT NULL,
+                                    secret TEXT NOT NULL,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Delivery log, every event is delivered to every subscribed webhook by a background job
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    webhook_id INT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+                                    event TEXT NOT NULL,
+                                    payload JSONB NOT NULL,
+                                    status TEXT NOT NULL DEFAULT 'pending'
+                                        CHECK (status IN ('pending', 'delivered', 'failed')),
+                                    attempts INT NOT NULL DEFAULT 0,
+                                    response_code INT,
+                                    last_error TEXT,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id, id DESC);
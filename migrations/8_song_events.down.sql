@@ -0,0 +1,7 @@
+-- Log of song lifecycle events
+DROP TRIGGER IF EXISTS songs_log_update ON songs;
+DROP TRIGGER IF EXISTS songs_log_insert_delete ON songs;
+DROP FUNCTION IF EXISTS songs_log_event();
+
+DROP TABLE IF EXISTS song_events;
+DROP FUNCTION IF EXISTS song_events_notify();
@@ -0,0 +1,68 @@
+-- Log of song lifecycle events, it is streamed by GET /events and replayed from Last-Event-ID.
+-- Events are written by triggers, so every change of songs is logged in its transaction
+CREATE TABLE IF NOT EXISTS song_events (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    event TEXT NOT NULL,
+                                    group_name TEXT NOT NULL,
+                                    song_name TEXT NOT NULL,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_song_events_created_at ON song_events (created_at);
+
+CREATE OR REPLACE FUNCTION songs_log_event() RETURNS trigger AS $$
+DECLARE
+    song RECORD;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        song = OLD;
+    ELSE
+        song = NEW;
+    END IF;
+
+    -- Writers are serialized until commit, so ids are committed in order and a client
+    -- resuming from Last-Event-ID cannot miss an event committed late with a lower id
+    PERFORM pg_advisory_xact_lock(hashtext('song_events'));
+
+    INSERT INTO song_events (event, group_name, song_name)
+    SELECT CASE TG_OP
+               WHEN 'INSERT' THEN 'song.created'
+               WHEN 'UPDATE' THEN 'song.updated'
+               ELSE 'song.deleted'
+           END,
+           g.name,
+           song.name
+    FROM groups g
+    WHERE g.id = song.group_id;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Link checks and touches by translations are not changes of the song data
+CREATE TRIGGER songs_log_insert_delete
+    AFTER INSERT OR DELETE ON songs
+    FOR EACH ROW EXECUTE FUNCTION songs_log_event();
+
+CREATE TRIGGER songs_log_update
+    AFTER UPDATE OF name, group_id, release_date, text, link ON songs
+    FOR EACH ROW
+    WHEN ((OLD.name, OLD.group_id, OLD.release_date, OLD.text, OLD.link)
+        IS DISTINCT FROM (NEW.name, NEW.group_id, NEW.release_date, NEW.text, NEW.link))
+    EXECUTE FUNCTION songs_log_event();
+
+-- Every replica listens to the channel, the notification is sent on commit
+CREATE OR REPLACE FUNCTION song_events_notify() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('song_events', json_build_object(
+        'id', NEW.id,
+        'event', NEW.event,
+        'song', json_build_object('group', NEW.group_name, 'song', NEW.song_name),
+        'occurredAt', NEW.created_at)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER song_events_notify
+    AFTER INSERT ON song_events
+    FOR EACH ROW EXECUTE FUNCTION song_events_notify();
@@ -0,0 +1,2 @@
+-- Transactional outbox
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,19 @@
+-- Transactional outbox of song lifecycle events. Events are added in the transaction
+-- of the change and published by the relay, so an event is never lost by a crash
+-- between the change and its publishing. event_id is the idempotency key of consumers
+CREATE TABLE IF NOT EXISTS outbox (
+                                    id BIGSERIAL PRIMARY KEY,
+                                    event_id UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+                                    event TEXT NOT NULL,
+                                    payload JSONB NOT NULL,
+                                    attempts INT NOT NULL DEFAULT 0,
+                                    last_error TEXT,
+                                    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    locked_until TIMESTAMPTZ,
+                                    published_at TIMESTAMPTZ,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (next_attempt_at, id) WHERE published_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_published_at ON outbox (published_at) WHERE published_at IS NOT NULL;
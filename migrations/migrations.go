@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL migrations of the database schema into the binary,
+// so the server does not depend on the working directory.
+package migrations
+
+import "embed"
+
+// FS contains the migration files named <version>_<title>.<up|down>.sql
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,289 @@
+// Package client is a Go client of the song library REST API.
+//
+// Every endpoint of swagger/song-library.yaml has a typed method. Requests are
+// authenticated by an API key or a JWT, failed requests that are safe to repeat are
+// retried with exponential backoff, and error responses are returned as *APIError,
+// matched with errors.Is against ErrNotFound, ErrAlreadyExists and the other sentinels.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultRetries      = 2
+	defaultRetryBackoff = 200 * time.Millisecond
+	// maxRetryBackoff caps the exponential backoff and the Retry-After delay
+	maxRetryBackoff = 10 * time.Second
+	// headerAPIKey is the request header with the API key
+	headerAPIKey = "X-API-Key"
+)
+
+// Client calls the REST API, it is safe for concurrent use.
+type Client struct {
+	baseURL      *url.URL
+	httpClient   *http.Client
+	apiKey       string
+	bearerToken  string
+	userAgent    string
+	retries      int
+	retryBackoff time.Duration
+}
+
+// Option configures the Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client, e.g. with custom TLS settings. The default client has a 30s timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAPIKey authenticates the requests by the API key, API keys have the admin role.
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithBearerToken authenticates the requests by the JWT.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.bearerToken = token
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetries sets how many times a failed request is repeated and the delay before the first
+// repeat, the delay doubles with every attempt. Network errors, 502, 503 and 504 are retried for
+// GET, PUT and DELETE, 429 Too Many Requests is retried for all methods after its Retry-After.
+// Zero retries disables retrying.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.retryBackoff = backoff
+	}
+}
+
+// New returns the client of the server at the base URL, e.g. https://songs.example.com.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base URL: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" || parsed.Host == "" {
+		return nil, fmt.Errorf("client: base URL %q is not an absolute http or https URL", baseURL)
+	}
+
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+
+	c := &Client{
+		baseURL:      parsed,
+		httpClient:   &http.Client{Timeout: defaultTimeout},
+		userAgent:    "song-library-client",
+		retries:      defaultRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// call is a request of the route.
+type call struct {
+	route route
+	// pathParams replace the {name} placeholders of the route path
+	pathParams map[string]string
+	query      url.Values
+	header     http.Header
+	// body is sent as JSON if it is not nil
+	body any
+}
+
+// do sends the request, retrying it if it fails, and returns the response with a status below 300.
+// Responses with other statuses are closed and returned as *APIError. The caller closes the body.
+func (c *Client) do(ctx context.Context, cl call) (*http.Response, error) {
+	var body []byte
+
+	if cl.body != nil {
+		var err error
+
+		body, err = json.Marshal(cl.body)
+		if err != nil {
+			return nil, fmt.Errorf("client: failed to encode request body: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, cl, body)
+
+		if err == nil && resp.StatusCode < http.StatusMultipleChoices {
+			return resp, nil
+		}
+
+		if err == nil {
+			err = newAPIError(cl.route, resp)
+		}
+
+		delay, retry := c.retryDelay(cl.route, attempt, resp, err)
+		if !retry {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, cl call, body []byte) (*http.Response, error) {
+	path := cl.route.path
+	for name, value := range cl.pathParams {
+		path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(value))
+	}
+
+	target := *c.baseURL
+	target.Path += path
+	target.RawQuery = cl.query.Encode()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, cl.route.method, target.String(), bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to create request: %w", err)
+	}
+
+	for name, values := range cl.header {
+		req.Header[name] = values
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.Header.Set("User-Agent", c.userAgent)
+
+	switch {
+	case c.apiKey != "":
+		req.Header.Set(headerAPIKey, c.apiKey)
+	case c.bearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("client: %s %s: %w", cl.route.method, cl.route.path, err)
+	}
+
+	return resp, nil
+}
+
+// retryDelay reports whether the failed attempt is retried and after what delay.
+func (c *Client) retryDelay(r route, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= c.retries || !retryable(r, err) {
+		return 0, false
+	}
+
+	delay := min(c.retryBackoff<<attempt, maxRetryBackoff)
+
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay = min(time.Duration(seconds)*time.Second, maxRetryBackoff)
+		}
+	}
+
+	return delay, true
+}
+
+// retryable reports whether the request of the route may be repeated after the error.
+func retryable(r route, err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// The request may have been processed before the connection failed
+		return r.idempotent() && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+		// A rejected request is not processed, so it is safe to repeat for every method
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return r.idempotent()
+	default:
+		return false
+	}
+}
+
+// getJSON sends the request and decodes the JSON response into out.
+// It reports false if the response is 204 No Content.
+func (c *Client) getJSON(ctx context.Context, cl call, out any) (*http.Response, bool, error) {
+	resp, err := c.do(ctx, cl)
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNoContent {
+		return resp, false, nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, false, fmt.Errorf("client: %s %s: failed to decode response: %w",
+			cl.route.method, cl.route.path, err)
+	}
+
+	return resp, true, nil
+}
+
+// decodeOptional decodes the JSON response into out unless the body is empty.
+func decodeOptional(r route, resp *http.Response, out any) error {
+	err := json.NewDecoder(resp.Body).Decode(out)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("client: %s %s: failed to decode response: %w", r.method, r.path, err)
+	}
+
+	return nil
+}
+
+// exec sends the request and discards the response body.
+func (c *Client) exec(ctx context.Context, cl call) (*http.Response, error) {
+	resp, err := c.do(ctx, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return resp, nil
+}
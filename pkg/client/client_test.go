@@ -0,0 +1,350 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, append([]Option{WithRetries(2, time.Millisecond)}, opts...)...)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestNew(t *testing.T) {
+	for _, baseURL := range []string{"", "localhost:8080", "ftp://example.com", "http://"} {
+		_, err := New(baseURL)
+		require.Error(t, err, baseURL)
+	}
+
+	c, err := New("https://songs.example.com/api/")
+	require.NoError(t, err)
+	require.Equal(t, "/api", c.baseURL.Path)
+}
+
+func TestSongs(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/songs", r.URL.Path)
+
+		if r.URL.Query().Get("group") == "nobody" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		require.Equal(t, "Muse", r.URL.Query().Get("group"))
+		require.Equal(t, "broken", r.URL.Query().Get("link_status"))
+		require.Equal(t, "1", r.URL.Query().Get("page"))
+		require.Equal(t, "10", r.URL.Query().Get("limit"))
+
+		_, _ = fmt.Fprint(w, `{"songs": [{"group": "Muse", "song": "Uprising",
+			"songDetail": {"releaseDate": "16.07.2009", "text": "", "link": ""},
+			"linkCheck": {"status": "broken", "statusCode": 404, "checkedAt": "2024-01-02T03:04:05Z"}}],
+			"page": 1, "limit": 10, "items": 1}`)
+	})
+
+	page, err := c.Songs(context.Background(), SongsQuery{Group: "Muse", LinkStatus: LinkBroken})
+	require.NoError(t, err)
+
+	require.Len(t, page.Songs, 1)
+	require.Equal(t, "Uprising", page.Songs[0].Song.Song)
+	require.Equal(t, "16.07.2009", page.Songs[0].SongDetail.ReleaseDate)
+	require.Equal(t, LinkBroken, page.Songs[0].LinkCheck.Status)
+
+	page, err = c.Songs(context.Background(), SongsQuery{Group: "nobody"})
+	require.NoError(t, err)
+	require.Empty(t, page.Songs)
+}
+
+func TestSaveSong(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		jobID  int64
+		err    error
+	}{
+		{
+			name:   "Enrichment enqueued",
+			status: http.StatusCreated,
+			body:   `{"enrichJobId": 42}`,
+			jobID:  42,
+		},
+		{
+			name:   "Enrichment disabled",
+			status: http.StatusCreated,
+		},
+		{
+			name:   "Song exists",
+			status: http.StatusAlreadyReported,
+			err:    ErrAlreadyExists,
+		},
+		{
+			name:   "Forbidden",
+			status: http.StatusForbidden,
+			err:    ErrForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+				w.WriteHeader(tc.status)
+				_, _ = fmt.Fprint(w, tc.body)
+			})
+
+			result, err := c.SaveSong(context.Background(), Song{Group: "Muse", Song: "Uprising"})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, tc.status, apiErr.StatusCode)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.jobID, result.EnrichJobID)
+		})
+	}
+}
+
+func TestInfo(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("song") == "missing" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		require.Equal(t, "de", r.URL.Query().Get("lang"))
+
+		w.Header().Set("Content-Language", "de")
+		w.Header().Set("Last-Modified", updatedAt.Format(http.TimeFormat))
+		_, _ = fmt.Fprint(w, `{"releaseDate": "16.07.2009", "text": "Text", "link": "https://example.com"}`)
+	})
+
+	info, err := c.Info(context.Background(), Song{Group: "Muse", Song: "Uprising"}, "de")
+	require.NoError(t, err)
+	require.Equal(t, "Text", info.Text)
+	require.Equal(t, "de", info.Lang)
+	require.True(t, updatedAt.Equal(info.UpdatedAt))
+
+	_, err = c.Info(context.Background(), Song{Group: "Muse", Song: "missing"}, "")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDeleteSongNotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.DeleteSong(context.Background(), Song{Group: "Muse", Song: "Uprising"})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestAuthHeaders(t *testing.T) {
+	var apiKey, authorization string
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("X-API-Key")
+		authorization = r.Header.Get("Authorization")
+
+		_, _ = fmt.Fprint(w, `{"status": "ok"}`)
+	}
+
+	_, err := newTestClient(t, handler, WithAPIKey("key")).Live(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "key", apiKey)
+	require.Empty(t, authorization)
+
+	_, err = newTestClient(t, handler, WithBearerToken("token")).Live(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, apiKey)
+	require.Equal(t, "Bearer token", authorization)
+}
+
+func TestRetries(t *testing.T) {
+	cases := []struct {
+		name     string
+		call     func(c *Client) error
+		statuses []int
+		attempts int32
+		err      error
+	}{
+		{
+			name: "GET retried on 503",
+			call: func(c *Client) error {
+				_, err := c.Job(context.Background(), 1)
+				return err
+			},
+			statuses: []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			attempts: 3,
+		},
+		{
+			name: "GET fails after the retries",
+			call: func(c *Client) error {
+				_, err := c.Job(context.Background(), 1)
+				return err
+			},
+			statuses: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			attempts: 3,
+			err:      ErrServerError,
+		},
+		{
+			name: "POST not retried on 503",
+			call: func(c *Client) error {
+				_, err := c.SaveSong(context.Background(), Song{Group: "Muse", Song: "Uprising"})
+				return err
+			},
+			statuses: []int{http.StatusServiceUnavailable, http.StatusCreated},
+			attempts: 1,
+			err:      ErrServerError,
+		},
+		{
+			name: "POST retried on 429",
+			call: func(c *Client) error {
+				_, err := c.SaveSong(context.Background(), Song{Group: "Muse", Song: "Uprising"})
+				return err
+			},
+			statuses: []int{http.StatusTooManyRequests, http.StatusCreated},
+			attempts: 2,
+		},
+		{
+			name: "Not found not retried",
+			call: func(c *Client) error {
+				_, err := c.Job(context.Background(), 1)
+				return err
+			},
+			statuses: []int{http.StatusNotFound, http.StatusOK},
+			attempts: 1,
+			err:      ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				status := tc.statuses[attempts.Add(1)-1]
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "0")
+				}
+
+				w.WriteHeader(status)
+
+				if status == http.StatusOK {
+					_, _ = fmt.Fprint(w, `{"id": 1, "status": "done"}`)
+				}
+			})
+
+			err := tc.call(c)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tc.attempts, attempts.Load())
+		})
+	}
+}
+
+func TestEventsResume(t *testing.T) {
+	var connections atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+
+		// The first stream breaks after two events, the second resumes after them
+		if connections.Add(1) == 1 {
+			require.Empty(t, r.URL.Query().Get("lastEventId"))
+
+			_, _ = fmt.Fprint(w, ": ping\n\n")
+			_, _ = fmt.Fprint(w, "id: 1\nevent: song.created\ndata: {\"id\": 1, \"event\": \"song.created\"}\n\n")
+			_, _ = fmt.Fprint(w, "id: 2\nevent: song.updated\ndata: {\"id\": 2, \"event\": \"song.updated\"}\n\n")
+			return
+		}
+
+		require.Equal(t, "2", r.URL.Query().Get("lastEventId"))
+
+		_, _ = fmt.Fprint(w, "id: 3\nevent: song.deleted\ndata: {\"id\": 3, \"event\": \"song.deleted\"}\n\n")
+	})
+
+	errStop := errors.New("stop")
+
+	var events []string
+
+	err := c.Events(context.Background(), 0, func(event SongEvent) error {
+		events = append(events, event.Event)
+
+		if event.ID == 3 {
+			return errStop
+		}
+
+		return nil
+	})
+
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, []string{EventSongCreated, EventSongUpdated, EventSongDeleted}, events)
+}
+
+func TestGraphQL(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"data": {"deleteSong": null},
+			"errors": [{"message": "role is not allowed", "extensions": {"code": "FORBIDDEN"}}]}`)
+	})
+
+	var data struct {
+		DeleteSong *bool `json:"deleteSong"`
+	}
+
+	err := c.GraphQL(context.Background(), `mutation { deleteSong(song: {group: "a", song: "b"}) }`, nil, &data)
+
+	var gqlErrs GraphQLErrors
+	require.ErrorAs(t, err, &gqlErrs)
+	require.Equal(t, "FORBIDDEN", gqlErrs[0].Extensions.Code)
+	require.Nil(t, data.DeleteSong)
+}
+
+func TestReadyUnavailable(t *testing.T) {
+	var attempts atomic.Int32
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = fmt.Fprint(w, `{"status": "unavailable", "checks": {"database": "connection refused"}}`)
+	})
+
+	health, err := c.Ready(context.Background())
+	require.ErrorIs(t, err, ErrServerError)
+	require.Equal(t, "unavailable", health.Status)
+	require.Equal(t, "connection refused", health.Checks["database"])
+	require.EqualValues(t, 1, attempts.Load())
+}
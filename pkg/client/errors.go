@@ -0,0 +1,83 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody is the number of bytes of an error response kept in APIError
+const maxErrorBody = 4 << 10
+
+// Errors matched by errors.Is against *APIError
+var (
+	ErrBadRequest       = errors.New("bad request")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrForbidden        = errors.New("forbidden")
+	ErrNotFound         = errors.New("not found")
+	ErrAlreadyExists    = errors.New("already exists")
+	ErrRateLimited      = errors.New("rate limited")
+	ErrServerError      = errors.New("server error")
+	ErrUnexpectedStatus = errors.New("unexpected status")
+)
+
+// APIError is a response with an error status. The API reports a missing song of some
+// endpoints with 204 No Content and an existing one with 208 Already Reported, they are
+// APIError too when the method can not return them as a result.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Body is the beginning of the response body, most errors have none
+	Body string
+}
+
+func newAPIError(r route, resp *http.Response) *APIError {
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	return &APIError{
+		Method:     r.method,
+		Path:       r.path,
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+	}
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("client: %s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+
+	return msg
+}
+
+// Is matches the error against the sentinel of its status.
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return target == ErrBadRequest
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound, http.StatusNoContent:
+		return target == ErrNotFound
+	case http.StatusAlreadyReported:
+		return target == ErrAlreadyExists
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+
+	if e.StatusCode >= http.StatusInternalServerError {
+		return target == ErrServerError
+	}
+
+	return target == ErrUnexpectedStatus
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Events streams the song events logged after the event lastEventID, zero streams only new events,
+// and calls handle for every event in order. The stream is resumed from the last handled event
+// when the connection is lost. Events returns when ctx is done, handle fails or the server
+// rejects the request.
+func (c *Client) Events(ctx context.Context, lastEventID int64, handle func(SongEvent) error) error {
+	// The stream has no end, so the timeout of the client must not apply
+	stream := *c
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+	stream.httpClient = &httpClient
+	stream.retries = 0
+
+	failures := 0
+
+	for {
+		received, err := stream.streamEvents(ctx, &lastEventID, handle)
+
+		var handleErr handlerError
+		if errors.As(err, &handleErr) {
+			return handleErr.err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && !retryable(routeEvents, err) {
+			return err
+		}
+
+		if received {
+			failures = 0
+		}
+
+		delay := min(c.retryBackoff<<min(failures, 16), maxRetryBackoff)
+		failures++
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// handlerError is the error of the handler, it stops the stream.
+type handlerError struct {
+	err error
+}
+
+func (e handlerError) Error() string {
+	return e.err.Error()
+}
+
+// streamEvents reads one stream until it ends and reports whether any events were received.
+func (c *Client) streamEvents(ctx context.Context, lastEventID *int64,
+	handle func(SongEvent) error) (bool, error) {
+	query := url.Values{}
+	if *lastEventID > 0 {
+		query.Set("lastEventId", strconv.FormatInt(*lastEventID, 10))
+	}
+
+	resp, err := c.do(ctx, call{route: routeEvents, query: query})
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	received := false
+	scanner := bufio.NewScanner(resp.Body)
+
+	var data strings.Builder
+
+	for scanner.Scan() {
+		field, value, _ := strings.Cut(scanner.Text(), ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "data":
+			data.WriteString(value)
+		case "":
+			// A blank line ends the event, a line starting with a colon is a comment
+			if scanner.Text() != "" || data.Len() == 0 {
+				continue
+			}
+
+			var event SongEvent
+			if err = json.Unmarshal([]byte(data.String()), &event); err != nil {
+				return received, fmt.Errorf("client: %s %s: failed to decode event: %w",
+					routeEvents.method, routeEvents.path, err)
+			}
+
+			data.Reset()
+
+			if err = handle(event); err != nil {
+				return received, handlerError{err: err}
+			}
+
+			*lastEventID = event.ID
+			received = true
+		}
+	}
+
+	return received, scanner.Err()
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Job returns the background job, e.g. the enrichment of a saved song. It requires the editor role.
+func (c *Client) Job(ctx context.Context, jobID int64) (*Job, error) {
+	var job Job
+
+	_, _, err := c.getJSON(ctx, call{route: routeJob, pathParams: idParam(jobID)}, &job)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// GraphQLError is an error of the GraphQL query, the code is in the extensions.
+type GraphQLError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+// GraphQLErrors are the errors of a GraphQL response.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+
+	return "client: graphql: " + strings.Join(messages, "; ")
+}
+
+// GraphQL runs the query with the variables and decodes its data into out. If the response
+// has errors, GraphQLErrors are returned and out is filled with the data that was resolved.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]any, out any) error {
+	body := struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables,omitempty"`
+	}{Query: query, Variables: variables}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+
+	_, _, err := c.getJSON(ctx, call{route: routeGraphQL, body: body}, &resp)
+	if err != nil {
+		return err
+	}
+
+	if out != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		if err = json.Unmarshal(resp.Data, out); err != nil {
+			return fmt.Errorf("client: graphql: failed to decode data: %w", err)
+		}
+	}
+
+	if len(resp.Errors) > 0 {
+		return resp.Errors
+	}
+
+	return nil
+}
+
+// Live calls the liveness probe.
+func (c *Client) Live(ctx context.Context) (*Health, error) {
+	var health Health
+
+	_, _, err := c.getJSON(ctx, call{route: routeLive}, &health)
+	if err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
+
+// Ready calls the readiness probe. If the server is not ready, the checks are returned
+// with the *APIError of 503 Service Unavailable, the probe is not retried.
+func (c *Client) Ready(ctx context.Context) (*Health, error) {
+	// A failing probe is the answer, not a reason to retry
+	noRetry := *c
+	noRetry.retries = 0
+
+	var health Health
+
+	_, _, err := noRetry.getJSON(ctx, call{route: routeReady}, &health)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Body != "" {
+		_ = json.Unmarshal([]byte(apiErr.Body), &health)
+		return &health, err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
+
+// Metrics returns the metrics in the Prometheus text format.
+func (c *Client) Metrics(ctx context.Context) (string, error) {
+	resp, err := c.do(ctx, call{route: routeMetrics})
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	metrics, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("client: %s %s: failed to read response: %w", routeMetrics.method, routeMetrics.path, err)
+	}
+
+	return string(metrics), nil
+}
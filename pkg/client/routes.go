@@ -0,0 +1,43 @@
+package client
+
+import "net/http"
+
+// route is an endpoint of the API, the path is the template of swagger/song-library.yaml.
+type route struct {
+	method string
+	path   string
+}
+
+func (r route) idempotent() bool {
+	return r.method != http.MethodPost
+}
+
+var (
+	routeSongs             = route{http.MethodGet, "/songs"}
+	routeSaveSong          = route{http.MethodPost, "/songs"}
+	routeUpdateSong        = route{http.MethodPut, "/songs"}
+	routeDeleteSong        = route{http.MethodDelete, "/songs"}
+	routeSongText          = route{http.MethodGet, "/songs/text"}
+	routeTranslations      = route{http.MethodGet, "/songs/translations"}
+	routeSaveTranslation   = route{http.MethodPost, "/songs/translations"}
+	routeUpdateTranslation = route{http.MethodPut, "/songs/translations"}
+	routeInfo              = route{http.MethodGet, "/info"}
+	routeJob               = route{http.MethodGet, "/jobs/{id}"}
+	routeEvents            = route{http.MethodGet, "/events"}
+	routeGraphQL           = route{http.MethodPost, "/graphql"}
+	routeCreateWebhook     = route{http.MethodPost, "/webhooks"}
+	routeWebhooks          = route{http.MethodGet, "/webhooks"}
+	routeDeleteWebhook     = route{http.MethodDelete, "/webhooks/{id}"}
+	routeWebhookDeliveries = route{http.MethodGet, "/webhooks/{id}/deliveries"}
+	routeLive              = route{http.MethodGet, "/healthz"}
+	routeReady             = route{http.MethodGet, "/readyz"}
+	routeMetrics           = route{http.MethodGet, "/metrics"}
+)
+
+// routes are all endpoints the client calls, the test checks them against the swagger spec.
+var routes = []route{
+	routeSongs, routeSaveSong, routeUpdateSong, routeDeleteSong, routeSongText,
+	routeTranslations, routeSaveTranslation, routeUpdateTranslation, routeInfo, routeJob,
+	routeEvents, routeGraphQL, routeCreateWebhook, routeWebhooks, routeDeleteWebhook,
+	routeWebhookDeliveries, routeLive, routeReady, routeMetrics,
+}
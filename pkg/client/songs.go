@@ -0,0 +1,196 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Songs returns a page of the songs matching the query, an empty page if none match.
+func (c *Client) Songs(ctx context.Context, q SongsQuery) (*SongsPage, error) {
+	page, limit := q.Page, q.Limit
+	if page == 0 {
+		page = 1
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("limit", strconv.Itoa(limit))
+	setIfNotEmpty(query, "group", q.Group)
+	setIfNotEmpty(query, "song", q.Song)
+	setIfNotEmpty(query, "date", q.ReleaseDate)
+	setIfNotEmpty(query, "link", q.Link)
+	setIfNotEmpty(query, "link_status", string(q.LinkStatus))
+
+	var songs SongsPage
+
+	_, ok, err := c.getJSON(ctx, call{route: routeSongs, query: query}, &songs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return &SongsPage{Songs: []SongWithDetail{}, Page: page, Limit: limit}, nil
+	}
+
+	return &songs, nil
+}
+
+// SaveSong adds the song, ErrAlreadyExists is returned if the song exists.
+func (c *Client) SaveSong(ctx context.Context, song Song) (*SaveResult, error) {
+	resp, err := c.do(ctx, call{route: routeSaveSong, body: song})
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusAlreadyReported {
+		return nil, newAPIError(routeSaveSong, resp)
+	}
+
+	var result SaveResult
+
+	// The body is empty if the enrichment is disabled
+	if err = decodeOptional(routeSaveSong, resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// UpdateSong replaces the details of the song, ErrNotFound is returned if the song does not exist.
+func (c *Client) UpdateSong(ctx context.Context, song SongWithDetail) error {
+	_, err := c.exec(ctx, call{route: routeUpdateSong, body: song})
+	return err
+}
+
+// DeleteSong deletes the song, ErrNotFound is returned if the song does not exist.
+func (c *Client) DeleteSong(ctx context.Context, song Song) error {
+	resp, err := c.exec(ctx, call{route: routeDeleteSong, body: song})
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNoContent {
+		return &APIError{Method: routeDeleteSong.method, Path: routeDeleteSong.path, StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// Info returns the details of the song with the text translated to lang if the translation
+// exists, the original text is returned for empty lang. ErrNotFound is returned if the song does not exist.
+func (c *Client) Info(ctx context.Context, song Song, lang string) (*SongInfo, error) {
+	query := url.Values{}
+	query.Set("group", song.Group)
+	query.Set("song", song.Song)
+	setIfNotEmpty(query, "lang", lang)
+
+	var info SongInfo
+
+	resp, ok, err := c.getJSON(ctx, call{route: routeInfo, query: query}, &info.SongDetail)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, &APIError{Method: routeInfo.method, Path: routeInfo.path, StatusCode: http.StatusNoContent}
+	}
+
+	info.Lang = resp.Header.Get("Content-Language")
+	info.UpdatedAt, _ = http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return &info, nil
+}
+
+// SongText returns the page of the song text, ErrNotFound is returned
+// if the song does not exist or the page is beyond the text.
+func (c *Client) SongText(ctx context.Context, q TextQuery) (*SongText, error) {
+	page := q.Page
+	if page == 0 {
+		page = 1
+	}
+
+	query := url.Values{}
+	query.Set("group", q.Song.Group)
+	query.Set("song", q.Song.Song)
+	query.Set("page", strconv.Itoa(page))
+	setIfNotEmpty(query, "by", string(q.By))
+	setIfNotEmpty(query, "lang", q.Lang)
+
+	if q.Size > 0 {
+		query.Set("size", strconv.Itoa(q.Size))
+	}
+	if q.Unique {
+		query.Set("unique", "true")
+	}
+
+	var text SongText
+
+	_, ok, err := c.getJSON(ctx, call{route: routeSongText, query: query}, &text)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, &APIError{Method: routeSongText.method, Path: routeSongText.path, StatusCode: http.StatusNoContent}
+	}
+
+	return &text, nil
+}
+
+// Translations returns all translations of the song, none if the song has none or does not exist.
+func (c *Client) Translations(ctx context.Context, song Song) ([]Translation, error) {
+	query := url.Values{}
+	query.Set("group", song.Group)
+	query.Set("song", song.Song)
+
+	var resp struct {
+		Translations []Translation `json:"translations"`
+	}
+
+	_, ok, err := c.getJSON(ctx, call{route: routeTranslations, query: query}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return []Translation{}, nil
+	}
+
+	return resp.Translations, nil
+}
+
+// SaveTranslation adds the translation of the song. ErrNotFound is returned
+// if the song does not exist, ErrAlreadyExists if the translation exists.
+func (c *Client) SaveTranslation(ctx context.Context, translation Translation) error {
+	resp, err := c.exec(ctx, call{route: routeSaveTranslation, body: translation})
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusAlreadyReported {
+		return &APIError{Method: routeSaveTranslation.method, Path: routeSaveTranslation.path, StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// UpdateTranslation replaces the text of the translation, ErrNotFound is returned if it does not exist.
+func (c *Client) UpdateTranslation(ctx context.Context, translation Translation) error {
+	_, err := c.exec(ctx, call{route: routeUpdateTranslation, body: translation})
+	return err
+}
+
+func setIfNotEmpty(query url.Values, key string, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
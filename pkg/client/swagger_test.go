@@ -0,0 +1,44 @@
+package client
+
+import (
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestRoutesMatchSwagger keeps the client in sync with the spec: every operation
+// of the spec has a route of the client and the client calls no other routes.
+func TestRoutesMatchSwagger(t *testing.T) {
+	data, err := os.ReadFile("../../swagger/song-library.yaml")
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]any `yaml:"paths"`
+	}
+	require.NoError(t, yaml.Unmarshal(data, &spec))
+
+	var specRoutes []string
+
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			if method == "parameters" {
+				continue
+			}
+
+			specRoutes = append(specRoutes, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	clientRoutes := make([]string, 0, len(routes))
+	for _, r := range routes {
+		clientRoutes = append(clientRoutes, r.method+" "+r.path)
+	}
+
+	slices.Sort(specRoutes)
+	slices.Sort(clientRoutes)
+
+	require.Equal(t, specRoutes, clientRoutes)
+}
@@ -0,0 +1,192 @@
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Song struct {
+	Group string `json:"group"`
+	Song  string `json:"song"`
+}
+
+type SongDetail struct {
+	// ReleaseDate is dd.mm.yyyy
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+type SongWithDetail struct {
+	Song
+	SongDetail SongDetail `json:"songDetail"`
+	// LinkCheck is nil if the link is not checked yet
+	LinkCheck *LinkCheck `json:"linkCheck,omitempty"`
+}
+
+type LinkStatus string
+
+const (
+	LinkOK          LinkStatus = "ok"
+	LinkBroken      LinkStatus = "broken"
+	LinkUnreachable LinkStatus = "unreachable"
+	// LinkUnchecked only filters songs whose links are not checked yet
+	LinkUnchecked LinkStatus = "unchecked"
+)
+
+// LinkCheck is the result of the last check of a song link.
+type LinkCheck struct {
+	Status     LinkStatus `json:"status"`
+	StatusCode int        `json:"statusCode,omitempty"`
+	RedirectTo string     `json:"redirectTo,omitempty"`
+	CheckedAt  time.Time  `json:"checkedAt"`
+}
+
+// SongsQuery filters and paginates GET /songs, empty filters match all songs.
+type SongsQuery struct {
+	Group string
+	Song  string
+	// ReleaseDate is dd.mm.yyyy
+	ReleaseDate string
+	Link        string
+	LinkStatus  LinkStatus
+	// Page starts from 1, Page and Limit default to 1 and 10
+	Page  int
+	Limit int
+}
+
+type SongsPage struct {
+	Songs []SongWithDetail `json:"songs"`
+	Page  int              `json:"page"`
+	Limit int              `json:"limit"`
+	Items int              `json:"items"`
+}
+
+// SaveResult is the result of POST /songs.
+type SaveResult struct {
+	// EnrichJobID is the job filling the song details, zero if the enrichment is disabled
+	EnrichJobID int64 `json:"enrichJobId"`
+}
+
+// SongInfo is the result of GET /info.
+type SongInfo struct {
+	SongDetail
+	// Lang is the language of the translated text, empty for the original text
+	Lang string
+	// UpdatedAt is the time of the last change of the song
+	UpdatedAt time.Time
+}
+
+type TextPagination string
+
+const (
+	ByVerse TextPagination = "verse"
+	ByLine  TextPagination = "line"
+	ByChars TextPagination = "chars"
+)
+
+// TextQuery selects the page of the song text of GET /songs/text.
+type TextQuery struct {
+	Song Song
+	// Page starts from 1, it defaults to 1
+	Page int
+	// By defaults to verses, Size is the number of verses, lines or characters per page
+	By     TextPagination
+	Size   int
+	Unique bool
+	// Lang requests a translation of the text
+	Lang string
+}
+
+type SongText struct {
+	Group      string `json:"group"`
+	Song       string `json:"song"`
+	Text       string `json:"text"`
+	Page       int    `json:"page"`
+	TotalPages int    `json:"totalPages"`
+	Lang       string `json:"lang,omitempty"`
+}
+
+type Translation struct {
+	Song
+	// Lang is a BCP 47 language tag, e.g. en, de-AT
+	Lang string `json:"lang"`
+	Text string `json:"text"`
+}
+
+type JobStatus string
+
+const (
+	JobPending JobStatus = "pending"
+	JobRunning JobStatus = "running"
+	JobDone    JobStatus = "done"
+	JobDead    JobStatus = "dead"
+)
+
+type Job struct {
+	ID          int64           `json:"id"`
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      JobStatus       `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"maxAttempts"`
+	LastError   string          `json:"lastError,omitempty"`
+	RunAt       time.Time       `json:"runAt"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+}
+
+// Song lifecycle events of webhooks and GET /events
+const (
+	EventSongCreated = "song.created"
+	EventSongUpdated = "song.updated"
+	EventSongDeleted = "song.deleted"
+)
+
+type SongEvent struct {
+	ID         int64     `json:"id"`
+	Event      string    `json:"event"`
+	Song       Song      `json:"song"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	// Secret signs the payloads, at least 16 characters
+	Secret string `json:"secret"`
+}
+
+type Webhook struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID           int64           `json:"id"`
+	WebhookID    int             `json:"webhookId"`
+	Event        string          `json:"event"`
+	Payload      json.RawMessage `json:"payload"`
+	Status       DeliveryStatus  `json:"status"`
+	Attempts     int             `json:"attempts"`
+	ResponseCode int             `json:"responseCode,omitempty"`
+	LastError    string          `json:"lastError,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+}
+
+// Health is the result of the probes, Checks are set by the readiness probe.
+type Health struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// CreateWebhook subscribes the URL to the song events, it requires the admin role.
+func (c *Client) CreateWebhook(ctx context.Context, req WebhookRequest) (*Webhook, error) {
+	var webhook Webhook
+
+	_, _, err := c.getJSON(ctx, call{route: routeCreateWebhook, body: req}, &webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// Webhooks returns all webhook subscriptions, it requires the admin role.
+func (c *Client) Webhooks(ctx context.Context) ([]Webhook, error) {
+	var resp struct {
+		Webhooks []Webhook `json:"webhooks"`
+	}
+
+	_, _, err := c.getJSON(ctx, call{route: routeWebhooks}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Webhooks, nil
+}
+
+// DeleteWebhook deletes the webhook with its delivery log, ErrNotFound is returned if it does not exist.
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID int) error {
+	_, err := c.exec(ctx, call{route: routeDeleteWebhook, pathParams: idParam(webhookID)})
+	return err
+}
+
+// WebhookDeliveries returns up to limit deliveries of the webhook, the newest first.
+// Zero limit uses the server default.
+func (c *Client) WebhookDeliveries(ctx context.Context, webhookID int, limit int) ([]WebhookDelivery, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var resp struct {
+		Deliveries []WebhookDelivery `json:"deliveries"`
+	}
+
+	_, _, err := c.getJSON(ctx, call{route: routeWebhookDeliveries, pathParams: idParam(webhookID), query: query}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Deliveries, nil
+}
+
+func idParam[T int | int64](id T) map[string]string {
+	return map[string]string{"id": strconv.FormatInt(int64(id), 10)}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/brianvoe/gofakeit/v6"
 	"net/http"
 	"song-library/internal/models"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -230,6 +231,7 @@ func TestSongTextGet_HappyPath(t *testing.T) {
 			HasValue("group", group).
 			HasValue("song", song).
 			HasValue("page", page).
+			HasValue("totalPages", testCount).
 			HasValue("text", pages[page-1])
 	}
 
@@ -237,5 +239,102 @@ func TestSongTextGet_HappyPath(t *testing.T) {
 		WithQuery("group", group).
 		WithQuery("song", song).
 		WithQuery("page", testCount+1).
+		Expect().Status(204).
+		Header("X-Total-Pages").IsEqual(strconv.Itoa(testCount))
+}
+
+func TestTranslation_HappyPath(t *testing.T) {
+	e := httpExpect(t)
+
+	group := gofakeit.AppAuthor() + " " + gofakeit.Animal()
+	song := gofakeit.BookTitle() + " " + gofakeit.Animal()
+
+	e.POST("/songs").
+		WithJSON(models.Song{
+			GroupName: group,
+			SongName:  song,
+		}).
+		Expect().Status(201)
+
+	var songObject models.SongWithDetail
+	songObject.GroupName = group
+	songObject.SongName = song
+	songObject.SongDetail = models.SongDetail{Text: "original verse"}
+
+	e.PUT("/songs").
+		WithJSON(songObject).
+		Expect().Status(200)
+
+	translation := models.SongTranslation{
+		Song: models.Song{GroupName: group, SongName: song},
+		Lang: "de",
+		Text: "deutscher Vers",
+	}
+
+	e.POST("/songs/translations").
+		WithJSON(translation).
+		Expect().Status(201)
+
+	// Translation already exists
+	e.POST("/songs/translations").
+		WithJSON(translation).
+		Expect().Status(208)
+
+	e.GET("/info").
+		WithQuery("group", group).
+		WithQuery("song", song).
+		WithQuery("lang", "de").
+		Expect().Status(200).
+		JSON().Object().
+		HasValue("text", "deutscher Vers")
+
+	e.GET("/songs/text").
+		WithQuery("group", group).
+		WithQuery("song", song).
+		WithQuery("page", 1).
+		WithHeader("Accept-Language", "fr, de;q=0.5").
+		Expect().Status(200).
+		JSON().Object().
+		HasValue("text", "deutscher Vers").
+		HasValue("lang", "de")
+
+	// No translation, the original text is returned
+	e.GET("/info").
+		WithQuery("group", group).
+		WithQuery("song", song).
+		WithQuery("lang", "fr").
+		Expect().Status(200).
+		JSON().Object().
+		HasValue("text", "original verse")
+
+	e.GET("/songs/translations").
+		WithQuery("group", group).
+		WithQuery("song", song).
+		Expect().Status(200).
+		JSON().Object().
+		HasValue("items", 1)
+}
+
+func TestAuth_Unauthorized(t *testing.T) {
+	e := httpExpectAnonymous(t)
+
+	song := models.Song{
+		GroupName: gofakeit.AppAuthor(),
+		SongName:  gofakeit.BookTitle(),
+	}
+
+	e.POST("/songs").
+		WithJSON(song).
+		Expect().Status(401)
+
+	e.DELETE("/songs").
+		WithJSON(song).
+		WithHeader("X-API-Key", "sl_wrong").
+		Expect().Status(401)
+
+	// Read endpoints are public
+	e.GET("/info").
+		WithQuery("group", song.GroupName).
+		WithQuery("song", song.SongName).
 		Expect().Status(204)
 }
@@ -3,6 +3,8 @@ package tests
 import (
 	"github.com/gavv/httpexpect/v2"
 	"net/url"
+	"os"
+	"song-library/internal/http-server/mwauth"
 	"testing"
 )
 
@@ -11,6 +13,19 @@ const (
 )
 
 func httpExpect(t *testing.T) *httpexpect.Expect {
+	hostURL := url.URL{
+		Scheme: "http",
+		Host:   host,
+	}
+	// Mutating endpoints require API key,
+	// create it with 'song-library apikey create tests' and set API_KEY
+	return httpexpect.Default(t, hostURL.String()).
+		Builder(func(req *httpexpect.Request) {
+			req.WithHeader(mwauth.HeaderAPIKey, os.Getenv("API_KEY"))
+		})
+}
+
+func httpExpectAnonymous(t *testing.T) *httpexpect.Expect {
 	hostURL := url.URL{
 		Scheme: "http",
 		Host:   host,